@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -20,16 +21,18 @@ const (
 
 type App struct {
 	State        int
+	Scenario     simulation.Scenario
 	ConfigScreen *frontend.ConfigScreen
 	MainWindow   *frontend.MainWindow
 	GraphScreen  *frontend.GraphScreen
 	Sim          *simulation.Simulation
 }
 
-func NewApp() *App {
+func NewApp(scenario simulation.Scenario) *App {
 	rand.Seed(time.Now().UnixNano())
 	return &App{
 		State:        StateConfig,
+		Scenario:     scenario,
 		ConfigScreen: frontend.NewConfigScreen(),
 	}
 }
@@ -47,7 +50,7 @@ func (a *App) Update() error {
 		if a.MainWindow != nil {
 			a.MainWindow.Update()
 			if a.MainWindow.IsFinished {
-				a.GraphScreen = frontend.NewGraphScreen(a.Sim.GetHistory())
+				a.GraphScreen = frontend.NewGraphScreen(a.Sim)
 				a.State = StateStats
 			}
 		}
@@ -100,12 +103,19 @@ func (a *App) StartSimulation() {
 		params.InitAnimals,   
 		params.InitPlants,    
 		
-		// Proportions Profils dynamiques
-		params.WeightPragmatic, 
-		params.WeightCautious, 
-		params.WeightSelfish, 
-		params.WeightCollectivist,
+		// Proportions Profils dynamiques (première tribu)
+		params.Tribes[0].Weights[simulation.Pragmatic],
+		params.Tribes[0].Weights[simulation.Cautious],
+		params.Tribes[0].Weights[simulation.Selfish],
+		params.Tribes[0].Weights[simulation.Collectivist],
 	)
+	sim.SetInteractionGame(params.GameType)
+	sim.SetPredators(params.PredatorPacks, params.PackSize)
+	sim.SetAnimalDynamics(params.AnimalBreeding, params.AnimalSpawning)
+	sim.SetCalendar(params.SeasonDays, params.Seasons)
+	sim.SetScenario(a.Scenario)
+	// Même seule, la tribu part de sa zone de départ
+	sim.SetTribes(params.Tribes[:params.TribeCount])
 
 	sim.Start()
 
@@ -114,10 +124,21 @@ func (a *App) StartSimulation() {
 }
 
 func main() {
+	scenarioPath := flag.String("scenario", "", "fichier JSON des catastrophes (defaut : aucune catastrophe)")
+	flag.Parse()
+
+	scenario := simulation.DefaultScenario()
+	if *scenarioPath != "" {
+		var err error
+		if scenario, err = simulation.LoadScenario(*scenarioPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	ebiten.SetWindowSize(1050, 600)
 	ebiten.SetWindowTitle("IA04 - Simulation Préhistorique")
 	
-	app := NewApp()
+	app := NewApp(scenario)
 
 	if err := ebiten.RunGame(app); err != nil {
 		log.Fatal(err)
@@ -5,6 +5,7 @@ import (
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 
@@ -168,6 +169,24 @@ type HumanSprite struct {
 	anims        []humanAnim 
 	globalRow    int         
 	rowsPerSheet int        
+
+	// Teinte de la tribu (nil : couleurs d'origine)
+	Tint color.Color
+}
+
+func (s *HumanSprite) Draw(screen *ebiten.Image) {
+	if s.Tint == nil {
+		s.BaseSprite.Draw(screen)
+		return
+	}
+	sx := s.currentFrame * s.frameWidth
+	sy := s.currentAnim * s.frameHeight
+	subImg := s.sheet.SubImage(image.Rect(sx, sy, sx+s.frameWidth, sy+s.frameHeight)).(*ebiten.Image)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(s.x, s.y)
+	op.ColorScale.ScaleWithColor(s.Tint)
+	screen.DrawImage(subImg, op)
 }
 
 func NewHumanSprite() *HumanSprite {
@@ -257,4 +276,89 @@ func (v *VegetableSprite) SetPosition(x, y float64) {
 
 func (v *VegetableSprite) SetAnimationRow(row int) {
 	
-}
\ No newline at end of file
+}
+
+// WolfSprite : pas de planche d'images pour le loup, on la dessine (2 frames, ligne 0 = gauche, ligne 1 = droite)
+type WolfSprite struct {
+	BaseSprite
+}
+
+func NewWolfSprite() *WolfSprite {
+	const w, h = 24, 16
+	sheet := ebiten.NewImage(w*2, h*2)
+	body := color.RGBA{110, 110, 120, 255}
+	dark := color.RGBA{60, 60, 70, 255}
+
+	for row := 0; row < 2; row++ {
+		for frame := 0; frame < 2; frame++ {
+			ox := float64(frame * w)
+			oy := float64(row * h)
+			headX, tailX := ox, ox+w-6
+			if row == 1 {
+				headX, tailX = ox+w-7, ox
+			}
+			ebitenutil.DrawRect(sheet, ox+4, oy+4, w-8, 7, body)
+			ebitenutil.DrawRect(sheet, headX, oy+2, 7, 6, body)
+			ebitenutil.DrawRect(sheet, headX+2, oy+3, 2, 2, color.RGBA{230, 200, 40, 255})
+			ebitenutil.DrawRect(sheet, tailX, oy+5, 6, 2, dark)
+			// Pattes alternées selon la frame
+			legShift := float64(frame * 2)
+			ebitenutil.DrawRect(sheet, ox+5+legShift, oy+11, 2, 5, dark)
+			ebitenutil.DrawRect(sheet, ox+w-9-legShift, oy+11, 2, 5, dark)
+		}
+	}
+
+	return &WolfSprite{
+		BaseSprite: BaseSprite{
+			sheet:       sheet,
+			currentAnim: 0,
+			frameWidth:  w,
+			frameHeight: h,
+			frameCount:  2,
+			animSpeed:   10,
+		},
+	}
+}
+
+func (s *WolfSprite) SetAnimationRow(row int) {
+	if s.currentAnim != row {
+		s.currentAnim = row
+		s.currentFrame = 0
+		s.animTick = 0
+	}
+}
+
+// PlantSprite : une image par stade de croissance (ligne 0 = pousse, 1 = en croissance, 2 = mûre)
+type PlantSprite struct {
+	stages []*VegetableSprite
+	stage  int
+}
+
+func NewPlantSprite(width, height int, r, g, b uint8) *PlantSprite {
+	return &PlantSprite{
+		stages: []*VegetableSprite{
+			NewVegetableSprite(4, 4, 120, 200, 90),
+			NewVegetableSprite(width*2/3+1, height*2/3+1, r/2+60, g/2+100, b/2+40),
+			NewVegetableSprite(width, height, r, g, b),
+		},
+		stage: 2,
+	}
+}
+
+func (p *PlantSprite) Update() {}
+
+func (p *PlantSprite) Draw(screen *ebiten.Image) {
+	p.stages[p.stage].Draw(screen)
+}
+
+func (p *PlantSprite) SetPosition(x, y float64) {
+	for _, s := range p.stages {
+		s.SetPosition(x, y)
+	}
+}
+
+func (p *PlantSprite) SetAnimationRow(row int) {
+	if row >= 0 && row < len(p.stages) {
+		p.stage = row
+	}
+}
@@ -2,6 +2,7 @@ package frontend
 
 import (
 	"fmt"
+	"ia04project/pkg/simulation"
 	"image/color"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -19,10 +20,22 @@ type ConfigParams struct {
 	MaxPlants     int
 	MaxSteps      int
 	
-	WeightPragmatic    float64
-	WeightCautious     float64
-	WeightSelfish      float64
-	WeightCollectivist float64
+	// Poids des profils et zone de départ de chaque tribu (la tribu 1 seule par défaut)
+	TribeCount    int
+	Tribes        [simulation.MaxTribes]simulation.TribeConfig
+	SelectedTribe int
+
+	GameType simulation.GameType
+
+	PredatorPacks int
+	PackSize      int
+
+	AnimalBreeding bool
+	AnimalSpawning bool
+
+	SeasonDays     int
+	Seasons        [simulation.SeasonCount]simulation.SeasonParams
+	SelectedSeason simulation.Season
 }
 type Button struct {
 	X, Y, W, H int
@@ -60,13 +73,22 @@ func NewConfigScreen() *ConfigScreen {
 			MaxPlants:     300,
 			MaxSteps:      150000,
 			
-			WeightPragmatic:    1.0,
-			WeightCautious:     1.0,
-			WeightSelfish:      1.0,
-			WeightCollectivist: 1.0,
+			TribeCount: 1,
+			Tribes:     simulation.DefaultTribes(),
+
+			PredatorPacks: 2,
+			PackSize:      3,
+
+			AnimalBreeding: false,
+			AnimalSpawning: true,
+
+			SeasonDays: simulation.SeasonDays,
+			Seasons:    simulation.DefaultSeasons(),
 		},
 		IsDone: false,
 	}
+	// Une tribu seule occupe toute la carte, sauf si on lui choisit une zone
+	cs.Params.Tribes[0].Area = simulation.AreaAnywhere
 
 	yBase := 50
 	step := 35
@@ -104,18 +126,66 @@ func NewConfigScreen() *ConfigScreen {
 		{250, yBase + step*7, 30, 20, "-", func() { cs.Params.MaxSteps -= 500; if cs.Params.MaxSteps < 100 { cs.Params.MaxSteps = 100 } }},
 		{300, yBase + step*7, 30, 20, "+", func() { cs.Params.MaxSteps += 500 }},
 
-		// Profils (8, 9, 10, 11)
-		{250, yBase + step*8, 30, 20, "-", func() { cs.Params.WeightPragmatic -= 1; if cs.Params.WeightPragmatic < 0 { cs.Params.WeightPragmatic = 0 } }},
-		{300, yBase + step*8, 30, 20, "+", func() { cs.Params.WeightPragmatic += 1 }},
+		// Profils de la tribu réglée (8, 9, 10, 11)
+		{250, yBase + step*8, 30, 20, "-", func() { cs.decWeight(simulation.Pragmatic) }},
+		{300, yBase + step*8, 30, 20, "+", func() { cs.selectedTribe().Weights[simulation.Pragmatic] += 1 }},
+
+		{250, yBase + step*9, 30, 20, "-", func() { cs.decWeight(simulation.Cautious) }},
+		{300, yBase + step*9, 30, 20, "+", func() { cs.selectedTribe().Weights[simulation.Cautious] += 1 }},
+
+		{250, yBase + step*10, 30, 20, "-", func() { cs.decWeight(simulation.Selfish) }},
+		{300, yBase + step*10, 30, 20, "+", func() { cs.selectedTribe().Weights[simulation.Selfish] += 1 }},
+
+		{250, yBase + step*11, 30, 20, "-", func() { cs.decWeight(simulation.Collectivist) }},
+		{300, yBase + step*11, 30, 20, "+", func() { cs.selectedTribe().Weights[simulation.Collectivist] += 1 }},
+
+		// 12. Jeu d'interaction
+		{250, yBase + step*12, 30, 20, "<", func() { cs.Params.GameType = (cs.Params.GameType + 2) % 3 }},
+		{300, yBase + step*12, 30, 20, ">", func() { cs.Params.GameType = (cs.Params.GameType + 1) % 3 }},
 
-		{250, yBase + step*9, 30, 20, "-", func() { cs.Params.WeightCautious -= 1; if cs.Params.WeightCautious < 0 { cs.Params.WeightCautious = 0 } }},
-		{300, yBase + step*9, 30, 20, "+", func() { cs.Params.WeightCautious += 1 }},
+		// Colonne 2 - 0. Meutes de loups
+		{650, yBase, 30, 20, "-", func() { cs.Params.PredatorPacks -= 1; if cs.Params.PredatorPacks < 0 { cs.Params.PredatorPacks = 0 } }},
+		{700, yBase, 30, 20, "+", func() { cs.Params.PredatorPacks += 1 }},
 
-		{250, yBase + step*10, 30, 20, "-", func() { cs.Params.WeightSelfish -= 1; if cs.Params.WeightSelfish < 0 { cs.Params.WeightSelfish = 0 } }},
-		{300, yBase + step*10, 30, 20, "+", func() { cs.Params.WeightSelfish += 1 }},
+		// Colonne 2 - 1. Taille des meutes
+		{650, yBase + step, 30, 20, "-", func() { cs.Params.PackSize -= 1; if cs.Params.PackSize < 1 { cs.Params.PackSize = 1 } }},
+		{700, yBase + step, 30, 20, "+", func() { cs.Params.PackSize += 1 }},
 
-		{250, yBase + step*11, 30, 20, "-", func() { cs.Params.WeightCollectivist -= 1; if cs.Params.WeightCollectivist < 0 { cs.Params.WeightCollectivist = 0 } }},
-		{300, yBase + step*11, 30, 20, "+", func() { cs.Params.WeightCollectivist += 1 }},
+		// Colonne 2 - 2. Reproduction des animaux
+		{650, yBase + step*2, 80, 20, "On/Off", func() { cs.Params.AnimalBreeding = !cs.Params.AnimalBreeding }},
+
+		// Colonne 2 - 3. Apparition spontanée des animaux
+		{650, yBase + step*3, 80, 20, "On/Off", func() { cs.Params.AnimalSpawning = !cs.Params.AnimalSpawning }},
+
+		// Colonne 2 - 4. Jours par saison
+		{650, yBase + step*4, 30, 20, "-", func() { cs.Params.SeasonDays -= 1; if cs.Params.SeasonDays < 1 { cs.Params.SeasonDays = 1 } }},
+		{700, yBase + step*4, 30, 20, "+", func() { cs.Params.SeasonDays += 1 }},
+
+		// Colonne 2 - 5. Saison réglée par les lignes suivantes
+		{650, yBase + step*5, 30, 20, "<", func() { cs.Params.SelectedSeason = (cs.Params.SelectedSeason + simulation.SeasonCount - 1) % simulation.SeasonCount }},
+		{700, yBase + step*5, 30, 20, ">", func() { cs.Params.SelectedSeason = (cs.Params.SelectedSeason + 1) % simulation.SeasonCount }},
+
+		// Colonne 2 - 6, 7, 8. Facteurs de la saison choisie
+		{650, yBase + step*6, 30, 20, "-", func() { cs.selectedSeason().PlantSpawn = decFactor(cs.selectedSeason().PlantSpawn) }},
+		{700, yBase + step*6, 30, 20, "+", func() { cs.selectedSeason().PlantSpawn += 0.1 }},
+
+		{650, yBase + step*7, 30, 20, "-", func() { cs.selectedSeason().PlantGrowth = decFactor(cs.selectedSeason().PlantGrowth) }},
+		{700, yBase + step*7, 30, 20, "+", func() { cs.selectedSeason().PlantGrowth += 0.1 }},
+
+		{650, yBase + step*8, 30, 20, "-", func() { cs.selectedSeason().AnimalSpawn = decFactor(cs.selectedSeason().AnimalSpawn) }},
+		{700, yBase + step*8, 30, 20, "+", func() { cs.selectedSeason().AnimalSpawn += 0.1 }},
+
+		// Colonne 2 - 9. Nombre de tribus
+		{650, yBase + step*9, 30, 20, "-", func() { cs.setTribeCount(cs.Params.TribeCount - 1) }},
+		{700, yBase + step*9, 30, 20, "+", func() { cs.setTribeCount(cs.Params.TribeCount + 1) }},
+
+		// Colonne 2 - 10. Tribu réglée (poids des profils et zone de départ)
+		{650, yBase + step*10, 30, 20, "<", func() { cs.Params.SelectedTribe = (cs.Params.SelectedTribe + cs.Params.TribeCount - 1) % cs.Params.TribeCount }},
+		{700, yBase + step*10, 30, 20, ">", func() { cs.Params.SelectedTribe = (cs.Params.SelectedTribe + 1) % cs.Params.TribeCount }},
+
+		// Colonne 2 - 11. Zone de départ de la tribu réglée
+		{650, yBase + step*11, 30, 20, "<", func() { cs.selectedTribe().Area = (cs.selectedTribe().Area + simulation.AreaCount - 1) % simulation.AreaCount }},
+		{700, yBase + step*11, 30, 20, ">", func() { cs.selectedTribe().Area = (cs.selectedTribe().Area + 1) % simulation.AreaCount }},
 
 		{250, 520, 125, 40, "LANCER SIMULATION", func() { cs.IsDone = true }},
 	}
@@ -151,12 +221,82 @@ func (c *ConfigScreen) Draw(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Limite Max Plantes : %d", c.Params.MaxPlants), 20, y); y+=step
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Max Steps          : %d", c.Params.MaxSteps), 20, y); y+=step
 
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Poids Pragmatique  : %.0f", c.Params.WeightPragmatic), 20, y); y+=step
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Poids Prudent      : %.0f", c.Params.WeightCautious), 20, y); y+=step
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Poids Egoiste      : %.0f", c.Params.WeightSelfish), 20, y); y+=step
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Poids Collectiviste: %.0f", c.Params.WeightCollectivist), 20, y); y+=step
+	weights := c.selectedTribe().Weights
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Poids Pragmatique  : %.0f", weights[simulation.Pragmatic]), 20, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Poids Prudent      : %.0f", weights[simulation.Cautious]), 20, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Poids Egoiste      : %.0f", weights[simulation.Selfish]), 20, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Poids Collectiviste: %.0f", weights[simulation.Collectivist]), 20, y); y+=step
+
+	gameName := "Aucun"
+	if game := simulation.CreateGame(c.Params.GameType); game != nil {
+		gameName = game.GetName()
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Jeu: %s", gameName), 20, y); y+=step
+
+	// Deuxième colonne
+	y = 55
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Meutes de loups    : %d", c.Params.PredatorPacks), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Loups par meute    : %d", c.Params.PackSize), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Reprod. animaux    : %s", onOff(c.Params.AnimalBreeding)), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Apparition animaux : %s", onOff(c.Params.AnimalSpawning)), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Jours par saison   : %d", c.Params.SeasonDays), 420, y); y+=step
+
+	season := c.Params.Seasons[c.Params.SelectedSeason]
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Saison reglee      : %s", c.Params.SelectedSeason), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("  Pousse plantes   : x%.1f", season.PlantSpawn), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("  Croissance/valeur: x%.1f", season.PlantGrowth), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("  Apparition anim. : x%.1f", season.AnimalSpawn), 420, y); y+=step
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Tribus             : %d", c.Params.TribeCount), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Tribu reglee       : %d (poids a gauche)", c.Params.SelectedTribe+1), 420, y); y+=step
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("  Zone de depart   : %s", c.selectedTribe().Area), 420, y); y+=step
 
 	for _, b := range c.Buttons {
 		b.Draw(screen)
 	}
-}
\ No newline at end of file
+}
+
+func (c *ConfigScreen) selectedSeason() *simulation.SeasonParams {
+	return &c.Params.Seasons[c.Params.SelectedSeason]
+}
+
+func (c *ConfigScreen) selectedTribe() *simulation.TribeConfig {
+	return &c.Params.Tribes[c.Params.SelectedTribe]
+}
+
+func (c *ConfigScreen) decWeight(p simulation.Profile) {
+	w := &c.selectedTribe().Weights[p]
+	*w -= 1
+	if *w < 0 {
+		*w = 0
+	}
+}
+
+func (c *ConfigScreen) setTribeCount(n int) {
+	if n < 1 || n > simulation.MaxTribes {
+		return
+	}
+	// Dès qu'il y a plusieurs tribus, la première reprend sa zone par défaut
+	if n > 1 && c.Params.Tribes[0].Area == simulation.AreaAnywhere {
+		c.Params.Tribes[0].Area = simulation.DefaultTribes()[0].Area
+	}
+	c.Params.TribeCount = n
+	if c.Params.SelectedTribe >= n {
+		c.Params.SelectedTribe = n - 1
+	}
+}
+
+func decFactor(f float64) float64 {
+	f -= 0.1
+	if f < 0 {
+		f = 0
+	}
+	return f
+}
+
+func onOff(b bool) string {
+	if b {
+		return "Oui"
+	}
+	return "Non"
+}
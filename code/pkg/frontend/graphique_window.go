@@ -6,17 +6,37 @@ import (
 	"image/color"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	PagePopulations = iota
+	PageInteractions
+	PagePhase
+	PagePyramid
+	PageTribes
+	PageConflicts
+	PageMarket
+	PageTools
+	PageCount
 )
 
 type GraphScreen struct {
-	History []simulation.TurnData
+	Sim      *simulation.Simulation
+	History  []simulation.TurnData
+	Pyramid  [][simulation.ProfileCount]int
+	Survival [simulation.ProfileCount]simulation.ChildSurvival
+	Page     int
 }
 
-func NewGraphScreen(history []simulation.TurnData) *GraphScreen {
-	return &GraphScreen{History: history}
+func NewGraphScreen(sim *simulation.Simulation) *GraphScreen {
+	return &GraphScreen{Sim: sim, History: sim.GetHistory(), Pyramid: sim.GetAgePyramid(), Survival: sim.GetChildSurvival()}
 }
 
 func (g *GraphScreen) Update() error {
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		g.Page = (g.Page + 1) % PageCount
+	}
 	return nil
 }
 
@@ -29,12 +49,366 @@ func (g *GraphScreen) Draw(screen *ebiten.Image) {
 	}
 
 	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
-	
-	rectTop := Rect{X: 50, Y: 50, W: float64(w) - 100, H: float64(h)/2 - 80}
-	g.drawGlobalGraph(screen, rectTop)
+	ebitenutil.DebugPrintAt(screen, "TAB: page suivante", w-130, h-20)
+
+	switch g.Page {
+	case PagePopulations:
+		rectTop := Rect{X: 50, Y: 50, W: float64(w) - 100, H: float64(h)/2 - 80}
+		g.drawGlobalGraph(screen, rectTop)
+
+		rectBot := Rect{X: 50, Y: float64(h)/2 + 20, W: float64(w) - 100, H: float64(h)/2 - 80}
+		g.drawProfilesGraph(screen, rectBot)
+	case PageInteractions:
+		g.drawInteractionTable(screen, 50, 50)
+	case PagePhase:
+		g.drawPhasePlot(screen, Rect{X: 80, Y: 50, W: float64(w) - 160, H: float64(h) - 120})
+	case PageTribes:
+		rectTop := Rect{X: 50, Y: 50, W: float64(w) - 100, H: float64(h)/2 - 80}
+		g.drawTribesGraph(screen, rectTop, "POPULATION PAR TRIBU", func(d simulation.TurnData, t int) float64 {
+			return float64(d.Tribes.Alive[t])
+		})
+		rectBot := Rect{X: 50, Y: float64(h)/2 + 20, W: float64(w) - 100, H: float64(h)/2 - 80}
+		g.drawTribesGraph(screen, rectBot, "NOURRITURE MOYENNE RECUE PAR TRIBU", func(d simulation.TurnData, t int) float64 {
+			return d.Tribes.MeanFood[t]
+		})
+	case PageConflicts:
+		rectTop := Rect{X: 50, Y: 50, W: float64(w) - 100, H: float64(h)/2 - 80}
+		g.drawTribesGraph(screen, rectTop, "TERRITOIRE PAR TRIBU (cases)", func(d simulation.TurnData, t int) float64 {
+			return float64(d.TerritoryCells[t])
+		})
+		g.drawConflictGraph(screen, Rect{X: 50, Y: float64(h)/2 + 20, W: float64(w) - 100, H: float64(h)/2 - 80})
+	case PageMarket:
+		g.drawTradeGraph(screen, Rect{X: 50, Y: 50, W: float64(w) - 100, H: float64(h)/2 - 80})
+		g.drawPriceGraph(screen, Rect{X: 50, Y: float64(h)/2 + 20, W: float64(w) - 100, H: float64(h)/2 - 80})
+	case PageTools:
+		g.drawToolGraph(screen, Rect{X: 50, Y: 50, W: float64(w) - 100, H: float64(h)/2 - 80}, simulation.Spear)
+		g.drawToolGraph(screen, Rect{X: 50, Y: float64(h)/2 + 20, W: float64(w) - 100, H: float64(h)/2 - 80}, simulation.Basket)
+	case PagePyramid:
+		g.drawAgePyramids(screen, Rect{X: 60, Y: 60, W: float64(w) - 100, H: float64(h) - 230})
+		g.drawChildSurvival(screen, 60, h-140)
+	}
+}
+
+var profileNames = []string{"Egoiste", "Collectif", "Pragmat.", "Prudent"}
+
+// drawInteractionTable affiche les résultats du jeu par paire de profils
+func (g *GraphScreen) drawInteractionTable(screen *ebiten.Image, x, y int) {
+	m := g.Sim.GetInteractions()
+	if m == nil {
+		ebitenutil.DebugPrintAt(screen, "Aucun jeu d'interaction active", x, y)
+		return
+	}
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("INTERACTIONS : %s", m.Game.GetName()), x, y)
+	y += 20
+	ebitenutil.DebugPrintAt(screen, "Ligne = joueur, colonne = adversaire : gain moyen / taux de cooperation / parties", x, y)
+	y += 30
+
+	colW := 150
+	for j, name := range profileNames {
+		ebitenutil.DebugPrintAt(screen, name, x+110+j*colW, y)
+	}
+	y += 20
+
+	results := m.GetResults()
+	for i, name := range profileNames {
+		strategy := m.GetStrategy(simulation.Profile(i)).GetName()
+		ebitenutil.DebugPrintAt(screen, name, x, y)
+		ebitenutil.DebugPrintAt(screen, strategy, x, y+15)
+		for j := range profileNames {
+			r := results[i][j]
+			cell := fmt.Sprintf("%.2f / %.0f%% / %d", r.MeanPayoff(), r.CooperationRate()*100, r.Games)
+			ebitenutil.DebugPrintAt(screen, cell, x+110+j*colW, y)
+		}
+		y += 45
+	}
+}
+
+// drawPhasePlot trace les humains en fonction des animaux : un cycle proie-prédateur dessine une boucle
+func (g *GraphScreen) drawPhasePlot(screen *ebiten.Image, r Rect) {
+	ebitenutil.DebugPrintAt(screen, "PLAN DE PHASE (X = Animaux, Y = Humains, du clair au fonce avec le temps)", int(r.X), int(r.Y)-20)
+
+	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{240, 240, 240, 255})
+
+	maxAnimals, maxHumans := 10.0, 10.0
+	for _, d := range g.History {
+		if float64(d.AnimalsAlive) > maxAnimals { maxAnimals = float64(d.AnimalsAlive) }
+		if float64(d.HumansAlive) > maxHumans { maxHumans = float64(d.HumansAlive) }
+	}
+
+	ebitenutil.DrawLine(screen, r.X, r.Y+r.H, r.X+r.W, r.Y+r.H, color.Black)
+	ebitenutil.DrawLine(screen, r.X, r.Y, r.X, r.Y+r.H, color.Black)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%.0f", maxHumans), int(r.X)-30, int(r.Y))
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%.0f", maxAnimals), int(r.X+r.W)-20, int(r.Y+r.H)+5)
+
+	toScreen := func(d simulation.TurnData) (float64, float64) {
+		x := r.X + (float64(d.AnimalsAlive)/maxAnimals)*r.W
+		y := r.Y + r.H - (float64(d.HumansAlive)/maxHumans)*r.H
+		return x, y
+	}
+
+	n := len(g.History)
+	for i := 0; i < n-1; i++ {
+		x1, y1 := toScreen(g.History[i])
+		x2, y2 := toScreen(g.History[i+1])
+		shade := uint8(200 - 200*i/n)
+		ebitenutil.DrawLine(screen, x1, y1, x2, y2, color.RGBA{shade, shade, 255, 255})
+	}
+
+	// Point courant
+	x, y := toScreen(g.History[n-1])
+	ebitenutil.DrawRect(screen, x-3, y-3, 6, 6, color.RGBA{255, 0, 0, 255})
+}
 
-	rectBot := Rect{X: 50, Y: float64(h)/2 + 20, W: float64(w) - 100, H: float64(h)/2 - 80}
-	g.drawProfilesGraph(screen, rectBot)
+// drawAgePyramids trace une pyramide des âges par profil (tranches de AgeBandWidth ticks, les plus jeunes en bas)
+func (g *GraphScreen) drawAgePyramids(screen *ebiten.Image, r Rect) {
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("PYRAMIDES DES AGES PAR PROFIL (tranches de %d ticks ; vert = enfants, bleu = adultes, gris = anciens)", simulation.AgeBandWidth), int(r.X), int(r.Y)-40)
+
+	bands := len(g.Pyramid)
+	if bands == 0 {
+		ebitenutil.DebugPrintAt(screen, "Aucun humain vivant", int(r.X), int(r.Y))
+		return
+	}
+	maxCount := 1
+	for _, row := range g.Pyramid {
+		for _, c := range row {
+			if c > maxCount { maxCount = c }
+		}
+	}
+
+	panelW := r.W / float64(len(profileNames))
+	barH := r.H / float64(bands)
+	for p, name := range profileNames {
+		px := r.X + float64(p)*panelW
+		center := px + panelW/2
+		ebitenutil.DebugPrintAt(screen, name, int(center)-25, int(r.Y)-20)
+		ebitenutil.DrawLine(screen, center, r.Y, center, r.Y+r.H, color.Gray{180})
+
+		for band, row := range g.Pyramid {
+			col := color.RGBA{70, 110, 200, 255}
+			age := band * simulation.AgeBandWidth
+			if age < simulation.AdultAge {
+				col = color.RGBA{80, 180, 80, 255}
+			} else if age >= simulation.ElderAge {
+				col = color.RGBA{150, 150, 150, 255}
+			}
+			width := float64(row[p]) / float64(maxCount) * (panelW - 20)
+			y := r.Y + r.H - float64(band+1)*barH
+			ebitenutil.DrawRect(screen, center-width/2, y+1, width, barH-2, col)
+		}
+	}
+
+	// Âge des tranches, à gauche
+	for band := 0; band < bands; band++ {
+		y := r.Y + r.H - float64(band+1)*barH
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", band*simulation.AgeBandWidth), 5, int(y+barH/2)-8)
+	}
+}
+
+// drawChildSurvival affiche le devenir des enfants selon le profil de leurs parents
+func (g *GraphScreen) drawChildSurvival(screen *ebiten.Image, x, y int) {
+	ebitenutil.DebugPrintAt(screen, "SURVIE DES ENFANTS PAR PROFIL PARENTAL (naissances / adultes / morts / taux de survie)", x, y)
+	y += 20
+	for i, name := range profileNames {
+		c := g.Survival[i]
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%-10s %4d / %4d / %4d / %3.0f%%", name, c.Births, c.Adults, c.Deaths, c.Rate()*100), x, y)
+		y += 18
+	}
+}
+
+// drawTribesGraph trace une courbe par tribu
+func (g *GraphScreen) drawTribesGraph(screen *ebiten.Image, r Rect, title string, value func(simulation.TurnData, int) float64) {
+	n := g.Sim.GetTribeCount()
+
+	ebitenutil.DebugPrintAt(screen, title, int(r.X), int(r.Y)-20)
+	for t := 0; t < n; t++ {
+		ebitenutil.DrawRect(screen, r.X+300+float64(t)*80, r.Y-16, 8, 8, tribeColors[t])
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Tribu %d", t+1), int(r.X)+312+t*80, int(r.Y)-20)
+	}
+
+	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{240, 240, 240, 255})
+	g.drawDisasters(screen, r)
+
+	maxVal := 5.0
+	for _, d := range g.History {
+		for t := 0; t < n; t++ {
+			if value(d, t) > maxVal { maxVal = value(d, t) }
+		}
+	}
+
+	ebitenutil.DrawLine(screen, r.X, r.Y+r.H, r.X+r.W, r.Y+r.H, color.Black)
+	ebitenutil.DrawLine(screen, r.X, r.Y, r.X, r.Y+r.H, color.Black)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%.0f", maxVal), int(r.X)-30, int(r.Y))
+
+	stepX := r.W / float64(len(g.History))
+	for i := 0; i < len(g.History)-1; i++ {
+		x1 := r.X + float64(i)*stepX
+		x2 := r.X + float64(i+1)*stepX
+		for t := 0; t < n; t++ {
+			y1 := r.Y + r.H - (value(g.History[i], t)/maxVal)*r.H
+			y2 := r.Y + r.H - (value(g.History[i+1], t)/maxVal)*r.H
+			ebitenutil.DrawLine(screen, x1, y1, x2, y2, tribeColors[t])
+		}
+	}
+}
+
+// drawConflictGraph trace les coups portés entre tribus (rouge) et les morts au combat (noir), cumulés par période
+func (g *GraphScreen) drawConflictGraph(screen *ebiten.Image, r Rect) {
+	ebitenutil.DebugPrintAt(screen, "CONFLITS ENTRE TRIBUS (rouge = coups, noir = morts)", int(r.X), int(r.Y)-20)
+	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{240, 240, 240, 255})
+
+	// Regroupement par colonnes de quelques pixels : les combats sont rares à l'échelle d'un tick
+	columns := int(r.W / 4)
+	if columns > len(g.History) {
+		columns = len(g.History)
+	}
+	fights := make([]int, columns)
+	deaths := make([]int, columns)
+	for i, d := range g.History {
+		c := i * columns / len(g.History)
+		fights[c] += d.Fights
+		deaths[c] += d.FightDeaths
+	}
+	maxVal := 5
+	for _, f := range fights {
+		if f > maxVal { maxVal = f }
+	}
+
+	ebitenutil.DrawLine(screen, r.X, r.Y+r.H, r.X+r.W, r.Y+r.H, color.Black)
+	ebitenutil.DrawLine(screen, r.X, r.Y, r.X, r.Y+r.H, color.Black)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", maxVal), int(r.X)-30, int(r.Y))
+
+	colW := r.W / float64(columns)
+	for c := 0; c < columns; c++ {
+		x := r.X + float64(c)*colW
+		hF := float64(fights[c]) / float64(maxVal) * r.H
+		ebitenutil.DrawRect(screen, x, r.Y+r.H-hF, colW, hF, color.RGBA{220, 60, 60, 255})
+		hD := float64(deaths[c]) / float64(maxVal) * r.H
+		ebitenutil.DrawRect(screen, x, r.Y+r.H-hD, colW, hD, color.Black)
+	}
+}
+
+// drawTradeGraph trace les trocs conclus (vert) et les négociations échouées (gris), cumulés par période
+func (g *GraphScreen) drawTradeGraph(screen *ebiten.Image, r Rect) {
+	ebitenutil.DebugPrintAt(screen, "TROCS (vert = conclus, gris = echoues)", int(r.X), int(r.Y)-20)
+	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{240, 240, 240, 255})
+
+	columns := int(r.W / 4)
+	if columns > len(g.History) {
+		columns = len(g.History)
+	}
+	trades := make([]int, columns)
+	failed := make([]int, columns)
+	volume := uint(0)
+	tools := 0
+	for i, d := range g.History {
+		c := i * columns / len(g.History)
+		trades[c] += d.Trade.Trades
+		failed[c] += d.Trade.Failed
+		volume += d.Trade.Volume
+		tools += d.Trade.Tools
+	}
+	maxVal := 5
+	for c := range trades {
+		if trades[c]+failed[c] > maxVal { maxVal = trades[c] + failed[c] }
+	}
+
+	ebitenutil.DrawLine(screen, r.X, r.Y+r.H, r.X+r.W, r.Y+r.H, color.Black)
+	ebitenutil.DrawLine(screen, r.X, r.Y, r.X, r.Y+r.H, color.Black)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", maxVal), int(r.X)-30, int(r.Y))
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Volume: %d, outils: %d", volume, tools), int(r.X+r.W)-180, int(r.Y)-20)
+
+	colW := r.W / float64(columns)
+	for c := 0; c < columns; c++ {
+		x := r.X + float64(c)*colW
+		hT := float64(trades[c]) / float64(maxVal) * r.H
+		ebitenutil.DrawRect(screen, x, r.Y+r.H-hT, colW, hT, color.RGBA{60, 170, 80, 255})
+		hF := float64(failed[c]) / float64(maxVal) * r.H
+		ebitenutil.DrawRect(screen, x, r.Y+r.H-hT-hF, colW, hF, color.RGBA{150, 150, 150, 255})
+	}
+}
+
+// itemColors : une couleur par sorte d'objet échangé
+var itemColors = []color.RGBA{
+	simulation.PlantItem:      {60, 170, 80, 255},
+	simulation.MeatItem:       {170, 40, 40, 255},
+	simulation.CookedMeatItem: {120, 60, 20, 255},
+}
+
+// drawPriceGraph place un point par tick où un objet s'est échangé contre des plantes :
+// des prix qui se resserrent signalent l'émergence d'un marché
+func (g *GraphScreen) drawPriceGraph(screen *ebiten.Image, r Rect) {
+	ebitenutil.DebugPrintAt(screen, "PRIX EN PLANTES (rouge = viande, brun = cuite)", int(r.X), int(r.Y)-20)
+	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{240, 240, 240, 255})
+
+	maxPrice := 2.0
+	for _, d := range g.History {
+		for _, p := range d.Trade.Prices {
+			if p > maxPrice { maxPrice = p }
+		}
+	}
+
+	ebitenutil.DrawLine(screen, r.X, r.Y+r.H, r.X+r.W, r.Y+r.H, color.Black)
+	ebitenutil.DrawLine(screen, r.X, r.Y, r.X, r.Y+r.H, color.Black)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%.1f", maxPrice), int(r.X)-30, int(r.Y))
+
+	// Repère : un pour un
+	y1 := r.Y + r.H - r.H/maxPrice
+	ebitenutil.DrawLine(screen, r.X, y1, r.X+r.W, y1, color.RGBA{180, 180, 180, 255})
+
+	stepX := r.W / float64(len(g.History))
+	for i, d := range g.History {
+		for k, p := range d.Trade.Prices {
+			if p == 0 || k == int(simulation.PlantItem) {
+				continue
+			}
+			x := r.X + float64(i)*stepX
+			y := r.Y + r.H - (p/maxPrice)*r.H
+			ebitenutil.DrawRect(screen, x-2, y-2, 4, 4, itemColors[k])
+		}
+	}
+}
+
+// profileColors : mêmes couleurs que la courbe des profils (ordre de simulation.Profile)
+var profileColors = []color.RGBA{
+	simulation.Selfish:      {138, 43, 226, 255},
+	simulation.Collectivist: {255, 140, 0, 255},
+	simulation.Pragmatic:    {0, 255, 255, 255},
+	simulation.Cautious:     {218, 165, 32, 255},
+}
+
+// drawToolGraph trace la part de chaque profil équipée de l'outil, et le total fabriqué par profil
+func (g *GraphScreen) drawToolGraph(screen *ebiten.Image, r Rect, tool simulation.ToolKind) {
+	crafted := g.Sim.GetToolsCrafted()
+	title := fmt.Sprintf("PART DES HUMAINS EQUIPES : %s (fabriques :", tool)
+	for p := range profileNames {
+		title += fmt.Sprintf(" %s %d", profileNames[p], crafted[p][tool])
+	}
+	ebitenutil.DebugPrintAt(screen, title+")", int(r.X), int(r.Y)-20)
+	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{240, 240, 240, 255})
+
+	share := func(d simulation.TurnData, p int) float64 {
+		count := [simulation.ProfileCount]int{d.CountSelfish, d.CountCollectivist, d.CountPragmatic, d.CountCautious}[p]
+		if count == 0 {
+			return 0
+		}
+		return float64(d.ToolOwners[p][tool]) / float64(count)
+	}
+
+	ebitenutil.DrawLine(screen, r.X, r.Y+r.H, r.X+r.W, r.Y+r.H, color.Black)
+	ebitenutil.DrawLine(screen, r.X, r.Y, r.X, r.Y+r.H, color.Black)
+	ebitenutil.DebugPrintAt(screen, "100%", int(r.X)-35, int(r.Y))
+
+	stepX := r.W / float64(len(g.History))
+	for i := 0; i < len(g.History)-1; i++ {
+		x1 := r.X + float64(i)*stepX
+		x2 := r.X + float64(i+1)*stepX
+		for p := range profileColors {
+			y1 := r.Y + r.H - share(g.History[i], p)*r.H
+			y2 := r.Y + r.H - share(g.History[i+1], p)*r.H
+			ebitenutil.DrawLine(screen, x1, y1, x2, y2, profileColors[p])
+		}
+	}
 }
 
 type Rect struct {
@@ -45,6 +419,8 @@ func (g *GraphScreen) drawGlobalGraph(screen *ebiten.Image, r Rect) {
 	ebitenutil.DebugPrintAt(screen, "POPULATION TOTALE (Bleu=Humains, Rouge=Animaux, Vert=Plantes)", int(r.X), int(r.Y)-20)
 	
 	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{240, 240, 240, 255})
+	g.drawWinters(screen, r)
+	g.drawDisasters(screen, r)
 
 	maxPop := 10.0
 	for _, d := range g.History {
@@ -80,11 +456,44 @@ func (g *GraphScreen) drawGlobalGraph(screen *ebiten.Image, r Rect) {
 	}
 }
 
+// drawWinters grise les périodes d'hiver en fond des courbes
+func (g *GraphScreen) drawWinters(screen *ebiten.Image, r Rect) {
+	stepX := r.W / float64(len(g.History))
+	for i, d := range g.History {
+		if d.Season == simulation.Winter {
+			ebitenutil.DrawRect(screen, r.X+float64(i)*stepX, r.Y, stepX+1, r.H, color.RGBA{210, 225, 245, 255})
+		}
+	}
+}
+
+// drawDisasters marque les catastrophes par des bandes colorées en haut du graphique
+func (g *GraphScreen) drawDisasters(screen *ebiten.Image, r Rect) {
+	stepX := r.W / float64(len(g.History))
+	for i, d := range g.History {
+		for k := 0; k < int(simulation.DisasterCount); k++ {
+			if d.Disasters&(1<<uint(k)) != 0 {
+				ebitenutil.DrawRect(screen, r.X+float64(i)*stepX, r.Y+float64(k)*5, stepX+1, 5, disasterColors[k])
+			}
+		}
+	}
+
+	// Légende sous le graphique
+	x := int(r.X)
+	for k := 0; k < int(simulation.DisasterCount); k++ {
+		ebitenutil.DrawRect(screen, float64(x), r.Y+r.H+6, 8, 8, disasterColors[k])
+		name := simulation.DisasterKind(k).String()
+		ebitenutil.DebugPrintAt(screen, name, x+12, int(r.Y+r.H)+2)
+		x += 20 + len(name)*6
+	}
+}
+
 func (g *GraphScreen) drawProfilesGraph(screen *ebiten.Image, r Rect) {
-	ebitenutil.DebugPrintAt(screen, "PROFILS HUMAINS", int(r.X), int(r.Y)-20)
-	ebitenutil.DebugPrintAt(screen, "Cyan: Pragm | Jaune: Prudent | Violet: Egoiste | Orange: Collectif", int(r.X)+150, int(r.Y)-20)
+	ebitenutil.DebugPrintAt(screen, "PROFILS HUMAINS (fond bleu = hiver)", int(r.X), int(r.Y)-20)
+	ebitenutil.DebugPrintAt(screen, "Cyan: Pragm | Jaune: Prudent | Violet: Egoiste | Orange: Collectif", int(r.X)+230, int(r.Y)-20)
 
 	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, color.RGBA{240, 240, 240, 255})
+	g.drawWinters(screen, r)
+	g.drawDisasters(screen, r)
 
 	maxVal := 5.0
 	for _, d := range g.History {
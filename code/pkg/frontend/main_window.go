@@ -10,6 +10,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 const (
@@ -121,6 +122,13 @@ type MainWindow struct {
 
 	IsFinished bool
 	GameView   *ebiten.Image
+
+	// Calques de la nuit et de la lumière des feux
+	Darkness *ebiten.Image
+	Light    *ebiten.Image
+
+	// Réseau de confiance (touche T)
+	ShowTrust bool
 }
 
 func NewMainWindow(sim *simulation.Simulation) *MainWindow {
@@ -131,6 +139,8 @@ func NewMainWindow(sim *simulation.Simulation) *MainWindow {
 		LastPositions: make(map[uint]simulation.Position),
 		IsFinished:    false,
 		GameView:      ebiten.NewImage(GameWidth, GameHeight),
+		Darkness:      ebiten.NewImage(GameWidth, GameHeight),
+		Light:         ebiten.NewImage(GameWidth, GameHeight),
 	}
 
 	mw.StopButton = Button{
@@ -153,6 +163,10 @@ func NewMainWindow(sim *simulation.Simulation) *MainWindow {
 func (mw *MainWindow) Update() error {
 	mw.SpeedSlider.Update()
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		mw.ShowTrust = !mw.ShowTrust
+	}
+
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		mx, my := ebiten.CursorPosition()
 		if !mw.SpeedSlider.IsDragging {
@@ -207,11 +221,14 @@ func (mw *MainWindow) Update() error {
 		id := obj.GetID()
 		aliveIDs[id] = true
 		if _, exists := mw.SpriteMap[id]; !exists {
-			mw.createVegetableSprite(obj)
+			mw.createObjectSprite(obj)
 		}
 		if s, ok := mw.SpriteMap[id]; ok {
 			pos := obj.GetSprite().Position
 			s.SetPosition(pos.X, pos.Y)
+			if veg, isVeg := obj.(*simulation.Vegetable); isVeg {
+				s.SetAnimationRow(int(veg.GetStage()))
+			}
 		}
 	}
 
@@ -265,10 +282,24 @@ func (mw *MainWindow) Draw(screen *ebiten.Image) {
 	mw.SpeedSlider.Draw(screen)
 
 	mw.GameView.Fill(color.RGBA{34, 139, 34, 255})
+	mw.drawTerritory(mw.GameView)
+	mw.drawCamps(mw.GameView)
 	for _, s := range mw.SpriteMap {
 		s.Draw(mw.GameView)
 	}
 
+	mw.drawFloods(mw.GameView)
+	mw.drawInfected(mw.GameView)
+
+	mw.drawNight(mw.GameView)
+
+	if mw.ShowTrust || mw.SelectedAgent != nil {
+		mw.drawTrustNetwork(mw.GameView)
+	}
+	ebitenutil.DebugPrintAt(mw.GameView, "T: reseau de confiance", 5, GameHeight-20)
+	mw.drawEventLog(mw.GameView)
+	mw.drawDisasterBanners(mw.GameView)
+
 	if mw.SelectedAgent != nil {
 		pos := mw.SelectedAgent.GetSprite().Position
 		ebitenutil.DrawRect(mw.GameView, pos.X+27, pos.Y+10, 10, 10, color.White)
@@ -285,16 +316,20 @@ func (mw *MainWindow) drawSidebarInfo(screen *ebiten.Image) {
 	if len(stats) > 0 {
 		last := stats[len(stats)-1]
 		y := 20
-		line := 20
+		line := 15
 		ebitenutil.DebugPrintAt(screen, "--- STATISTIQUES ---", 10, y)
 		y += line
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Temps: %d", last.Tick), 10, y)
+		moment := "jour"
+		if mw.Sim.IsNight() {
+			moment = "nuit"
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Temps: %d (Jour %d, %s, %s)", last.Tick, last.Day, last.Season, moment), 10, y)
 		y += line
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Vitesse: x%d", mw.SpeedSlider.Current), 10, y)
 		y += line
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Humains: %d", last.HumansAlive), 10, y)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Humains: %d (enfants: %d, anciens: %d)", last.HumansAlive, last.Children, last.Elders), 10, y)
 		y += line
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Animaux: %d", last.AnimalsAlive), 10, y)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Animaux: %d (loups: %d)", last.AnimalsAlive, last.PredatorsAlive), 10, y)
 		y += line
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Plantes: %d", last.VegetablesAlive), 10, y)
 		y += line
@@ -305,11 +340,32 @@ func (mw *MainWindow) drawSidebarInfo(screen *ebiten.Image) {
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Egoïstes: %d", last.CountSelfish), 10, y)
 		y += line
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Collectivistes: %d", last.CountCollectivist), 10, y)
+		y += line
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Cooperation: %.0f%% Gini: %.2f", last.CooperationRate*100, last.GiniFood), 10, y)
+		y += line
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("SIR: %d sains / %d malades / %d immunises", last.Susceptible, last.Infected, last.Recovered), 10, y)
+		if n := mw.Sim.GetTribeCount(); n > 1 {
+			y += line
+			text := "Tribus:"
+			for t := 0; t < n; t++ {
+				text += fmt.Sprintf(" %d", last.Tribes.Alive[t])
+			}
+			ebitenutil.DebugPrintAt(screen, text, 10, y)
+		}
+		if camps := mw.Sim.GetCamps(); len(camps) > 0 {
+			y += line
+			// Réserve / solidité de l'abri de chaque campement
+			text := "Camps:"
+			for _, c := range camps {
+				text += fmt.Sprintf(" %d/%.0f", c.GetStock(), c.GetShelter())
+			}
+			ebitenutil.DebugPrintAt(screen, text, 10, y)
+		}
 	}
 
 	// Infos Agent Sélectionné
-	y := 250
-	line := 20
+	y := 230
+	line := 16
 	ebitenutil.DebugPrintAt(screen, "--- INSPECTION ---", 10, y)
 	y += line
 	if mw.SelectedAgent != nil {
@@ -325,6 +381,38 @@ func (mw *MainWindow) drawSidebarInfo(screen *ebiten.Image) {
 			y += line
 			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Energie: %d", h.GetEnergy()), 10, y)
 			y += line
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Nourriture recue: %d", h.GetFoodReceived()), 10, y)
+			y += line
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Sac: %s", h.GetInventorySummary()), 10, y)
+			y += line
+			tools, mats := h.GetTools(), h.GetMaterials()
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Outils: lance %d, panier %d (%d/%d/%d)", tools[simulation.Spear], tools[simulation.Basket],
+				mats[simulation.Stone], mats[simulation.Wood], mats[simulation.Flint]), 10, y)
+			y += line
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Etat: %s", h.GetHealthState()), 10, y)
+			y += line
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Age: %d (%s), enfants: %d", h.GetAge(), h.GetLifeStage(), len(h.GetChildrenIDs())), 10, y)
+			y += line
+			sex := h.GetSex().String()
+			if h.GetPregnancy() > 0 {
+				sex += fmt.Sprintf(" (enceinte, %d)", h.GetPregnancy())
+			}
+			if h.GetPartnerID() != 0 {
+				sex += fmt.Sprintf(", couple: %d", h.GetPartnerID())
+			}
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Sexe: %s", sex), 10, y)
+			y += line
+
+			trusted, distrusted := 0, 0
+			for _, t := range h.GetTrustScores() {
+				if t > simulation.InitialTrust {
+					trusted++
+				} else if t < simulation.InitialTrust {
+					distrusted++
+				}
+			}
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Confiance: +%d / -%d", trusted, distrusted), 10, y)
+			y += line
 
 			prof := "Inconnu"
 			switch h.GetProfile() {
@@ -351,6 +439,38 @@ func (mw *MainWindow) drawSidebarInfo(screen *ebiten.Image) {
 					action = "Chasse"
 				case *simulation.ReproduceAction:
 					action = "Reproduction"
+				case *simulation.ShareFoodAction:
+					action = "Partage"
+				case *simulation.FleeAction:
+					action = "Fuite"
+				case *simulation.CareAction:
+					action = "Soins"
+				case *simulation.FeedChildAction:
+					action = "Nourrit son enfant"
+				case *simulation.FollowParentAction:
+					action = "Suit ses parents"
+				case *simulation.FightAction:
+					action = "Combat"
+				case *simulation.BuildAction:
+					action = "Construit l'abri"
+				case *simulation.DepositAction:
+					action = "Remplit la reserve"
+				case *simulation.WithdrawAction:
+					action = "Puise dans la reserve"
+				case *simulation.EatAction:
+					action = "Mange ses provisions"
+				case *simulation.TradeAction:
+					action = "Troc"
+				case *simulation.CollectMaterialAction:
+					action = "Ramasse des materiaux"
+				case *simulation.CraftAction:
+					action = "Fabrique un outil"
+				case *simulation.LightFireAction:
+					action = "Allume un feu"
+				case *simulation.FeedFireAction:
+					action = "Entretient le feu"
+				case *simulation.CookAction:
+					action = "Cuisine"
 				}
 			}
 			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Action: %s", action), 10, y)
@@ -363,20 +483,216 @@ func (mw *MainWindow) drawSidebarInfo(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "--- VITESSE SIMULATION ---", 10, 480)
 }
 
+// drawEventLog affiche les derniers événements (blessures, morts...) en haut du jeu
+func (mw *MainWindow) drawEventLog(screen *ebiten.Image) {
+	events := mw.Sim.GetEvents(5)
+	if len(events) == 0 {
+		return
+	}
+	ebitenutil.DrawRect(screen, 0, 0, 330, float64(len(events)*16+6), color.RGBA{0, 0, 0, 120})
+	for i, e := range events {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("[%d] %s", e.Tick, e.Text), 5, 2+i*16)
+	}
+}
+
+// tribeColors : teinte des humains de chaque tribu (et de leurs courbes)
+var tribeColors = [simulation.MaxTribes]color.RGBA{
+	{255, 90, 90, 255},
+	{90, 140, 255, 255},
+	{240, 210, 60, 255},
+	{90, 220, 110, 255},
+}
+
+// disasterColors : une couleur par catastrophe (bandeaux et graphiques)
+var disasterColors = []color.RGBA{
+	simulation.Drought:  {200, 140, 40, 255},
+	simulation.Flood:    {40, 90, 200, 255},
+	simulation.ColdSnap: {120, 200, 230, 255},
+	simulation.Outbreak: {150, 60, 150, 255},
+}
+
+// drawTerritory teinte chaque case possédée par une tribu (seulement s'il y a plusieurs tribus)
+func (mw *MainWindow) drawTerritory(screen *ebiten.Image) {
+	if mw.Sim.GetTribeCount() < 2 {
+		return
+	}
+	owners, cols, cell := mw.Sim.GetTerritory()
+	for i, o := range owners {
+		if o < 0 {
+			continue
+		}
+		c := tribeColors[o]
+		x := float64(i%cols) * float64(cell)
+		y := float64(i/cols) * float64(cell)
+		ebitenutil.DrawRect(screen, x, y, float64(cell), float64(cell), color.RGBA{c.R / 5, c.G / 5, c.B / 5, 60})
+	}
+}
+
+// drawCamps dessine chaque campement : zone abritée, tente aux couleurs de la tribu et réserve
+func (mw *MainWindow) drawCamps(screen *ebiten.Image) {
+	for _, c := range mw.Sim.GetCamps() {
+		pos := c.GetSprite().Position
+		size := float64(simulation.CampSize)
+		r := simulation.CampRadius
+		ebitenutil.DrawRect(screen, pos.X-r+size/2, pos.Y-r+size/2, 2*r, 2*r, color.RGBA{40, 30, 10, 50})
+
+		col := tribeColors[c.GetTribe()]
+		if !c.IsSheltered() {
+			col = color.RGBA{col.R / 2, col.G / 2, col.B / 2, 255}
+		}
+		ebitenutil.DrawRect(screen, pos.X, pos.Y, size, size, color.RGBA{110, 80, 40, 255})
+		built := size * c.GetShelter() / simulation.MaxShelter
+		ebitenutil.DrawRect(screen, pos.X, pos.Y+size-built, size, built, col)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", c.GetStock()), int(pos.X), int(pos.Y+size))
+	}
+}
+
+// drawNight assombrit la carte selon l'heure (nuit noire à minuit) ;
+// chaque feu perce l'obscurité d'un halo de lumière de rayon FireRadius
+func (mw *MainWindow) drawNight(screen *ebiten.Image) {
+	darkness := uint8((1 - mw.Sim.GetDaylight()) * 170)
+	mw.Darkness.Fill(color.RGBA{0, 0, darkness / 6, darkness})
+
+	fires := mw.Sim.GetFires()
+	if len(fires) > 0 {
+		// Cercles concentriques : la lumière décroît vers le bord du halo
+		mw.Light.Clear()
+		for _, f := range fires {
+			pos := f.GetSprite().Position
+			cx := float32(pos.X + simulation.FireSize/2)
+			cy := float32(pos.Y + simulation.FireSize/2)
+			for i := 0; i < 4; i++ {
+				r := float32(simulation.FireRadius) * float32(4-i) / 4
+				vector.FillCircle(mw.Light, cx, cy, r, color.RGBA{0, 0, 0, 90}, true)
+			}
+		}
+		op := &ebiten.DrawImageOptions{Blend: ebiten.BlendDestinationOut}
+		mw.Darkness.DrawImage(mw.Light, op)
+	}
+	screen.DrawImage(mw.Darkness, nil)
+
+	// Lueur orangée, plus visible la nuit
+	glow := darkness / 4
+	for _, f := range fires {
+		pos := f.GetSprite().Position
+		cx := float32(pos.X + simulation.FireSize/2)
+		cy := float32(pos.Y + simulation.FireSize/2)
+		vector.FillCircle(screen, cx, cy, float32(simulation.FireRadius)/2, color.RGBA{glow, glow / 2, 0, glow}, true)
+	}
+}
+
+// drawFloods dessine les zones inondées
+func (mw *MainWindow) drawFloods(screen *ebiten.Image) {
+	for _, d := range mw.Sim.GetActiveDisasters() {
+		if d.Kind == simulation.Flood {
+			ebitenutil.DrawRect(screen, d.Zone.X, d.Zone.Y, d.Zone.W, d.Zone.H, color.RGBA{30, 70, 160, 200})
+		}
+	}
+}
+
+// drawInfected recouvre les humains malades d'un halo violet
+func (mw *MainWindow) drawInfected(screen *ebiten.Image) {
+	for _, a := range mw.Sim.GetAllAgents() {
+		if h, ok := a.(*simulation.Human); ok && h.IsAlive() && h.IsInfected() {
+			pos := h.GetSprite().Position
+			ebitenutil.DrawRect(screen, pos.X+16, pos.Y+8, HumanFrameWidth/2, HumanFrameHeight-16, color.RGBA{90, 20, 90, 110})
+		}
+	}
+}
+
+// drawDisasterBanners affiche un bandeau par catastrophe en cours, au-dessus de l'aide
+func (mw *MainWindow) drawDisasterBanners(screen *ebiten.Image) {
+	stats := mw.Sim.GetHistory()
+	if len(stats) == 0 {
+		return
+	}
+	tick := stats[len(stats)-1].Tick
+	y := GameHeight - 50
+	for _, d := range mw.Sim.GetActiveDisasters() {
+		ebitenutil.DrawRect(screen, 0, float64(y), 260, 22, disasterColors[d.Kind])
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s (encore %d ticks)", d.Kind, d.End-tick), 8, y+4)
+		y -= 26
+	}
+}
+
+// drawTrustNetwork trace les liens de confiance (vert) et de méfiance (rouge).
+// Si un humain est sélectionné, seuls ses liens sont affichés.
+func (mw *MainWindow) drawTrustNetwork(screen *ebiten.Image) {
+	humans := make(map[uint]*simulation.Human)
+	for _, a := range mw.Sim.GetAllAgents() {
+		if h, ok := a.(*simulation.Human); ok && h.IsAlive() {
+			humans[h.GetID()] = h
+		}
+	}
+
+	for _, h := range humans {
+		if mw.SelectedAgent != nil && mw.SelectedAgent.GetID() != h.GetID() {
+			continue
+		}
+		from := h.GetSprite().Position
+		for id, t := range h.GetTrustScores() {
+			other, ok := humans[id]
+			if !ok {
+				continue
+			}
+			delta := t - simulation.InitialTrust
+			if math.Abs(delta) < 0.1 {
+				continue
+			}
+			alpha := uint8(math.Min(255, 80+math.Abs(delta)*350))
+			c := color.RGBA{0, 200, 0, alpha}
+			if delta < 0 {
+				c = color.RGBA{220, 0, 0, alpha}
+			}
+			to := other.GetSprite().Position
+			ebitenutil.DrawLine(screen, from.X+32, from.Y+32, to.X+32, to.Y+32, c)
+		}
+	}
+}
+
 func (mw *MainWindow) Layout(w, h int) (int, int) {
 	return WindowWidth, GameHeight
 }
 
-func (mw *MainWindow) createVegetableSprite(obj simulation.Object) {
+func (mw *MainWindow) createObjectSprite(obj simulation.Object) {
+	if carcass, ok := obj.(*simulation.Carcass); ok {
+		s := NewVegetableSprite(14, 10, 139, 69, 19)
+		pos := carcass.GetSprite().Position
+		s.SetPosition(pos.X, pos.Y)
+		mw.SpriteMap[carcass.GetID()] = s
+		return
+	}
+	if f, ok := obj.(*simulation.Fire); ok {
+		s := NewVegetableSprite(simulation.FireSize, simulation.FireSize, 255, 120, 20)
+		pos := f.GetSprite().Position
+		s.SetPosition(pos.X, pos.Y)
+		mw.SpriteMap[f.GetID()] = s
+		return
+	}
+	if m, ok := obj.(*simulation.Material); ok {
+		var s *VegetableSprite
+		switch m.GetKind() {
+		case simulation.Stone:
+			s = NewVegetableSprite(simulation.MaterialSize, simulation.MaterialSize, 150, 150, 150)
+		case simulation.Wood:
+			s = NewVegetableSprite(simulation.MaterialSize+4, simulation.MaterialSize/2, 110, 70, 30)
+		case simulation.Flint:
+			s = NewVegetableSprite(simulation.MaterialSize/2, simulation.MaterialSize, 60, 60, 80)
+		}
+		pos := m.GetSprite().Position
+		s.SetPosition(pos.X, pos.Y)
+		mw.SpriteMap[m.GetID()] = s
+		return
+	}
 	if veg, ok := obj.(*simulation.Vegetable); ok {
 		var s Sprite
 		switch veg.GetType() {
 		case simulation.Carrot:
-			s = NewVegetableSprite(10, 10, 255, 165, 0)
+			s = NewPlantSprite(10, 10, 255, 165, 0)
 		case simulation.Lettuce:
-			s = NewVegetableSprite(12, 12, 50, 205, 50)
+			s = NewPlantSprite(12, 12, 50, 205, 50)
 		case simulation.Berry:
-			s = NewVegetableSprite(8, 8, 148, 0, 211)
+			s = NewPlantSprite(8, 8, 148, 0, 211)
 		}
 		if s != nil {
 			pos := veg.GetSprite().Position
@@ -438,6 +754,14 @@ func (mw *MainWindow) updateAgentAnimation(agent simulation.Agent, s Sprite, dx,
 				sTyped.SetAnimationRow(0)
 			}
 		}
+	case *WolfSprite:
+		if isMoving {
+			if dx > 0 {
+				sTyped.SetAnimationRow(1)
+			} else {
+				sTyped.SetAnimationRow(0)
+			}
+		}
 	}
 }
 
@@ -445,7 +769,11 @@ func (mw *MainWindow) createSpriteForAgent(agent simulation.Agent) {
 	var s Sprite
 	switch v := agent.(type) {
 	case *simulation.Human:
-		s = NewHumanSprite()
+		hs := NewHumanSprite()
+		if mw.Sim.GetTribeCount() > 1 {
+			hs.Tint = tribeColors[v.GetTribe()]
+		}
+		s = hs
 	case *simulation.Animal:
 		switch v.GetType() {
 		case simulation.Chicken:
@@ -455,6 +783,8 @@ func (mw *MainWindow) createSpriteForAgent(agent simulation.Agent) {
 		case simulation.Bull:
 			s = NewBullSprite()
 		}
+	case *simulation.Predator:
+		s = NewWolfSprite()
 	}
 	if s != nil {
 		pos := agent.GetSprite().Position
@@ -13,6 +13,11 @@ type Agent interface {
 	SetID(id uint)
 	GetEnergy() uint
 
+	// Communication
+	Send(msg Message)
+	Receive(msg Message)
+	ReadMessages() []Message
+
 	// Méthodes IA
 	Percept(env *Environment)
 	Deliberate()
@@ -33,6 +38,10 @@ type AgentParams struct {
 	alive  bool
 	sprite Sprite
 
+	// Messagerie (distribuée au tick suivant)
+	mailbox []Message
+	outbox  []Message
+
 	// Channels pour la synchronisation
 	syncChan chan bool
 	doneChan chan bool
@@ -0,0 +1,98 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	AdultAge           = 3000  // ticks (5 jours)
+	ElderAge           = 15000 // ticks
+	AgeBandWidth       = 3000  // tranches de la pyramide des âges
+	ChildHealth        = 60
+	ElderSpeedFactor   = 0.6
+	ElderVisionFactor  = 0.7
+	ElderDeathRate     = 0.00001 // risque par tick à l'entrée dans la vieillesse
+	AgingScale         = 3000.0  // le risque est multiplié par e tous les AgingScale ticks
+	ChildShareBonus    = 2.0     // priorité des enfants au partage de la viande
+	OrphanGatherFactor = 0.3     // un orphelin cueille, mais mal
+)
+
+type LifeStage int
+
+const (
+	Child LifeStage = iota
+	Adult
+	Elder
+)
+
+func (l LifeStage) String() string {
+	switch l {
+	case Child:
+		return "Enfant"
+	case Elder:
+		return "Ancien"
+	default:
+		return "Adulte"
+	}
+}
+
+func lifeStageAt(age int) LifeStage {
+	switch {
+	case age < AdultAge:
+		return Child
+	case age >= ElderAge:
+		return Elder
+	default:
+		return Adult
+	}
+}
+
+func (h *Human) GetAge() int {
+	return h.age
+}
+
+func (h *Human) GetLifeStage() LifeStage {
+	return lifeStageAt(h.age)
+}
+
+// speed : les anciens se déplacent plus lentement
+func (h *Human) speed() float64 {
+	if h.GetLifeStage() == Elder {
+		return MoveSpeed * ElderSpeedFactor
+	}
+	return MoveSpeed
+}
+
+// grow fait vieillir l'humain ; le risque de mort naturelle croît avec l'âge (loi de Gompertz)
+func (h *Human) grow(env *Environment) {
+	h.age++
+	if h.age == AdultAge {
+		env.families.record(h, func(c *ChildSurvival) { c.Adults++ })
+	}
+	if h.GetLifeStage() != Elder {
+		return
+	}
+	risk := ElderDeathRate * math.Exp(float64(h.age-ElderAge)/AgingScale)
+	if rand.Float64() < risk {
+		h.Kill()
+		env.LogEvent(EventDeath, "%s meurt de vieillesse", h.GetName())
+	}
+}
+
+// GetAgePyramid compte les humains vivants par tranche d'âge (AgeBandWidth) et par profil
+func (s *Simulation) GetAgePyramid() [][ProfileCount]int {
+	pyramid := [][ProfileCount]int{}
+	for _, a := range s.environment.agents {
+		h, ok := a.(*Human)
+		if !ok || !h.IsAlive() {
+			continue
+		}
+		band := h.age / AgeBandWidth
+		for len(pyramid) <= band {
+			pyramid = append(pyramid, [ProfileCount]int{})
+		}
+		pyramid[band][h.profile]++
+	}
+	return pyramid
+}
@@ -0,0 +1,115 @@
+package simulation
+
+import "math/rand"
+
+const (
+	DensityLimit = 8
+	BreedRate    = 0.03
+	MateRadius   = 200.0
+)
+
+// breeding : reproduction propre à chaque espèce (le taureau ne porte pas de petits)
+type breeding struct {
+	gestation int
+	minLitter int
+	maxLitter int
+}
+
+func (t AnimalType) breeding() breeding {
+	switch t {
+	case Cow:
+		return breeding{gestation: 600, minLitter: 1, maxLitter: 2}
+	case Chicken:
+		return breeding{gestation: 150, minLitter: 2, maxLitter: 4}
+	default:
+		return breeding{}
+	}
+}
+
+// sameSpecies : vaches et taureaux forment une seule espèce
+func (t AnimalType) sameSpecies(o AnimalType) bool {
+	if t == Chicken || o == Chicken {
+		return t == o
+	}
+	return true
+}
+
+// isMate : le poulet se reproduit avec n'importe quel poulet, la vache a besoin d'un taureau
+func (a *Animal) isMate(o *Animal) bool {
+	if a.typ == Cow {
+		return o.typ == Bull
+	}
+	return a.typ == o.typ
+}
+
+func AnimalSize(typ AnimalType) int {
+	switch typ {
+	case Chicken:
+		return 20
+	case Bull:
+		return 48
+	default:
+		return 32
+	}
+}
+
+func (a *Animal) IsPregnant() bool { return a.gestation > 0 }
+
+// canConceive : seul un animal en bonne santé, rassasié et reposé se reproduit
+func (a *Animal) canConceive() bool {
+	return a.typ.breeding().gestation > 0 &&
+		!a.IsPregnant() &&
+		a.hasMate &&
+		!a.isWounded() &&
+		a.hunger < a.typ.diet().maxHunger/2 &&
+		a.energy > AnimalMaxEnergy/4
+}
+
+// breed : la probabilité de concevoir baisse avec la densité locale de l'espèce
+func (a *Animal) breed(env *Environment) {
+	if a.IsPregnant() {
+		a.gestation--
+		if a.gestation == 0 {
+			a.giveBirth(env)
+		}
+		return
+	}
+	if !env.animalBreeding || !a.canConceive() || a.neighbours >= DensityLimit {
+		return
+	}
+	crowding := 1.0 - float64(a.neighbours)/DensityLimit
+	if rand.Float64() < BreedRate*crowding {
+		a.gestation = a.typ.breeding().gestation
+	}
+}
+
+func (a *Animal) giveBirth(env *Environment) {
+	b := a.typ.breeding()
+	litter := b.minLitter + rand.Intn(b.maxLitter-b.minLitter+1)
+	pos := a.GetSprite().Position
+
+	for i := 0; i < litter; i++ {
+		typ := a.typ
+		if typ == Cow && rand.Float64() < 0.5 {
+			typ = Bull
+		}
+		size := AnimalSize(typ)
+		x := pos.X + rand.Float64()*20 - 10
+		y := pos.Y + rand.Float64()*20 - 10
+		young := CreateAnimal("Jeune", CreateSprite(x, y, size, size), typ)
+		young.hunger = 0
+		young.SetID(env.newID())
+
+		// La portée s'arrête quand la carte est pleine
+		if !env.addYoung(young) {
+			break
+		}
+		young.Start(env)
+
+		// Chaque petit coûte à la mère
+		a.hunger += a.typ.diet().hungryAt / 2
+	}
+	if a.hunger > a.typ.diet().maxHunger {
+		a.hunger = a.typ.diet().maxHunger
+	}
+}
@@ -3,12 +3,23 @@ package simulation
 import (
 	"math"
 	"math/rand"
+	"sync"
 )
 
 const (
 	AnimalVisionRadius = 100.0
 	AnimalSpeed        = 0.5
 	WanderDuration     = 100
+	ChargeDuration     = 60
+	ChargeSpeedFactor  = 3.0
+	WoundedRatio       = 0.6
+	AggressionDecay    = 0.99
+	CounterAttackRate  = 0.15
+	ChargeRollRate     = 0.1
+	GrazeDuration      = 30
+	GrazeFactor        = 8
+	AnimalMaxEnergy    = 300
+	TiredSpeedFactor   = 0.5
 )
 
 type AnimalType int
@@ -25,8 +36,56 @@ const (
 	AnimalStateWander AnimalState = iota
 	AnimalStateFlee
 	AnimalStateStay
+	AnimalStateCharge
 )
 
+// combatStats : capacité d'un animal à se défendre
+type combatStats struct {
+	attack       int
+	chargeChance float64
+}
+
+func (t AnimalType) combat() combatStats {
+	switch t {
+	case Cow:
+		return combatStats{attack: 6, chargeChance: 0.15}
+	case Bull:
+		return combatStats{attack: 12, chargeChance: 0.35}
+	default:
+		return combatStats{attack: 1, chargeChance: 0.02}
+	}
+}
+
+// diet : appétit d'un animal (faim maximale avant de dépérir, seuil où il cherche à brouter)
+type diet struct {
+	maxHunger uint
+	hungryAt  uint
+}
+
+func (t AnimalType) diet() diet {
+	switch t {
+	case Cow:
+		return diet{maxHunger: 900, hungryAt: 300}
+	case Bull:
+		return diet{maxHunger: 1000, hungryAt: 350}
+	default:
+		return diet{maxHunger: 500, hungryAt: 150}
+	}
+}
+
+func (t AnimalType) String() string {
+	switch t {
+	case Chicken:
+		return "Poulet"
+	case Cow:
+		return "Vache"
+	case Bull:
+		return "Taureau"
+	default:
+		return "Animal"
+	}
+}
+
 type Animal struct {
 	AgentParams
 	typ             AnimalType
@@ -35,6 +94,38 @@ type Animal struct {
 	targetPos       Position
 	stepsInState    int
 	detectedThreats []Agent
+
+	// Alimentation
+	hunger     uint
+	energy     uint
+	food       *Vegetable
+	grazeSteps int
+
+	// Troupeau (bovins uniquement)
+	herd       []*Animal
+	leader     *Animal
+	herdAlarm  Position
+	alarmLevel float64 // force de l'alerte la plus forte reçue
+	signal     herdSignal
+	pasture    *Vegetable
+
+	night bool
+	cold  bool
+
+	// Reproduction
+	gestation  int
+	neighbours int
+	hasMate    bool
+
+	// Combat
+	maxHealth    int
+	aggression   float64
+	chargeTarget Agent
+
+	// Une seule carcasse par animal, même si plusieurs chasseurs l'achèvent
+	butchered bool
+	damageBy  map[uint]int
+	mutex     sync.Mutex
 }
 
 func CreateAnimal(name string, sprite Sprite, typ AnimalType) *Animal {
@@ -60,6 +151,10 @@ func CreateAnimal(name string, sprite Sprite, typ AnimalType) *Animal {
 		typ:          typ,
 		peopleNeeded: peopleNeeded,
 		state:        AnimalStateWander,
+		damageBy:     make(map[uint]int),
+		maxHealth:    health,
+		hunger:       uint(rand.Intn(int(typ.diet().hungryAt))),
+		energy:       AnimalMaxEnergy,
 	}
 }
 
@@ -83,8 +178,73 @@ func (a *Animal) Start(env *Environment) {
 }
 
 func (a *Animal) GetType() AnimalType       { return a.typ }
+func (a *Animal) GetHunger() uint           { return a.hunger }
+func (a *Animal) GetEnergy() uint           { return a.energy }
 func (a *Animal) GetPeopleNeeded() int      { return a.peopleNeeded }
 
+// hitBy enregistre les dégâts infligés par chaque chasseur
+func (a *Animal) hitBy(hunterID uint, damage int) {
+	a.mutex.Lock()
+	a.damageBy[hunterID] += damage
+	a.aggression = math.Min(1, a.aggression+0.5)
+	a.mutex.Unlock()
+	a.IsAttacked(damage)
+}
+
+func (a *Animal) GetAggression() float64 {
+	return a.aggression
+}
+
+func (a *Animal) isWounded() bool {
+	return float64(a.health) < float64(a.maxHealth)*WoundedRatio
+}
+
+// counterAttack : l'animal frappé riposte, d'autant plus fort que les chasseurs sont peu nombreux
+func (a *Animal) counterAttack(h *Human, hunters int, env *Environment) {
+	if hunters < 1 {
+		hunters = 1
+	}
+	stats := a.typ.combat()
+	ratio := float64(a.peopleNeeded) / float64(hunters)
+	if rand.Float64() < stats.chargeChance*ratio*CounterAttackRate {
+		a.strike(h, int(float64(stats.attack)*ratio), env)
+	}
+}
+
+func (a *Animal) strike(target Agent, damage int, env *Environment) {
+	if damage <= 0 || !target.IsAlive() {
+		return
+	}
+	target.IsAttacked(damage)
+	a.aggression *= 0.5
+	if target.IsAlive() {
+		env.LogEvent(EventInjury, "%s blesse par %s (-%d)", target.GetName(), a.typ, damage)
+	} else {
+		env.LogEvent(EventDeath, "%s tue par %s", target.GetName(), a.typ)
+	}
+}
+
+func (a *Animal) damageReport() map[uint]int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	report := make(map[uint]int, len(a.damageBy))
+	for id, d := range a.damageBy {
+		report[id] = d
+	}
+	return report
+}
+
+// butcher renvoie vrai pour le premier chasseur qui découpe l'animal
+func (a *Animal) butcher() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.butchered {
+		return false
+	}
+	a.butchered = true
+	return true
+}
+
 func (a *Animal) GetHungerValue() uint {
 	switch a.typ {
 	case Chicken: 
@@ -102,63 +262,253 @@ func (a *Animal) GetHungerValue() uint {
 
 func (a *Animal) Percept(env *Environment) {
 	a.detectedThreats = []Agent{}
+	a.perceptFood(env)
+
+	// Un animal affamé prend plus de risques pour manger
+	fearRadius := AnimalVisionRadius * env.visionFactor()
+	a.night = env.isNight()
+	a.cold = env.hasDisaster(ColdSnap)
+	if a.hunger >= a.typ.diet().hungryAt*2 {
+		fearRadius /= 2
+	}
 	// Utilisation de RLock si on veut être strict, mais ici on simplifie
 	// env.mutex.RLock(); defer env.mutex.RUnlock() (si ajouté dans env)
 	
+	a.neighbours = 0
+	a.hasMate = false
+
 	for _, agent := range env.agents {
-		if _, ok := agent.(*Human); ok && agent.IsAlive() {
+		if other, ok := agent.(*Animal); ok && other != a && other.IsAlive() && a.typ.sameSpecies(other.typ) {
+			d := a.GetSprite().Position.DistanceTo(other.GetSprite().Position)
+			if d < AnimalVisionRadius {
+				a.neighbours++
+			}
+			if d < MateRadius && a.isMate(other) {
+				a.hasMate = true
+			}
+			continue
+		}
+		_, isHuman := agent.(*Human)
+		_, isPredator := agent.(*Predator)
+		if (isHuman || isPredator) && agent.IsAlive() {
 			dist := a.GetSprite().Position.DistanceTo(agent.GetSprite().Position)
-			if dist < AnimalVisionRadius {
+			if dist < fearRadius {
 				a.detectedThreats = append(a.detectedThreats, agent)
 			}
 		}
 	}
+
+	a.perceptHerd(env)
+	if a.IsHerdLeader() {
+		a.perceptPasture(env)
+	}
+}
+
+// perceptPasture : le chef du troupeau repère la plante la plus proche comme prochaine destination
+func (a *Animal) perceptPasture(env *Environment) {
+	if a.pasture != nil && a.pasture.IsAlive() {
+		return
+	}
+	a.pasture = nil
+	minDist := HerdRadius * 2
+	for _, obj := range env.objects {
+		if veg, ok := obj.(*Vegetable); ok && veg.IsAlive() {
+			d := a.GetSprite().Position.DistanceTo(veg.GetSprite().Position)
+			if d < minDist {
+				minDist = d
+				a.pasture = veg
+			}
+		}
+	}
+}
+
+// perceptFood garde la plante visée tant qu'elle existe, sinon cherche la plus proche
+func (a *Animal) perceptFood(env *Environment) {
+	if a.food != nil && a.food.IsAlive() {
+		return
+	}
+	a.food = nil
+	a.grazeSteps = 0
+	if a.hunger < a.typ.diet().hungryAt {
+		return
+	}
+
+	minDist := AnimalVisionRadius
+	for _, obj := range env.objects {
+		if veg, ok := obj.(*Vegetable); ok && veg.IsAlive() {
+			d := a.GetSprite().Position.DistanceTo(veg.GetSprite().Position)
+			if d < minDist {
+				minDist = d
+				a.food = veg
+			}
+		}
+	}
 }
 
 func (a *Animal) Deliberate() {
+	a.aggression *= AggressionDecay
+
+	if a.state == AnimalStateCharge && a.chargeTarget != nil && a.chargeTarget.IsAlive() && a.stepsInState < ChargeDuration {
+		return
+	}
+	a.chargeTarget = nil
+
 	if len(a.detectedThreats) > 0 {
+		// Un animal blessé peut se retourner contre le chasseur le plus proche
+		if a.isWounded() && rand.Float64() < a.typ.combat().chargeChance*a.aggression*ChargeRollRate {
+			a.chargeTarget = a.closestThreat()
+			a.state = AnimalStateCharge
+			a.stepsInState = 0
+			return
+		}
+		a.state = AnimalStateFlee
+	} else if a.isHerdAlarmed() {
+		// Fuite collective : un membre a vu une menace
 		a.state = AnimalStateFlee
+	} else if a.food != nil && a.GetSprite().Position.DistanceTo(a.food.GetSprite().Position) <= ActionRange {
+		// Broute sur place
+		a.state = AnimalStateStay
+	} else if a.night && a.typ == Chicken && a.food == nil {
+		// Les poulets se perchent la nuit
+		a.state = AnimalStateStay
 	} else {
+		if a.state == AnimalStateStay {
+			a.grazeSteps = 0
+		}
 		a.state = AnimalStateWander
 	}
 }
 
+func (a *Animal) closestThreat() Agent {
+	var closest Agent
+	minDist := math.Inf(1)
+	for _, t := range a.detectedThreats {
+		d := a.GetSprite().Position.DistanceTo(t.GetSprite().Position)
+		if d < minDist {
+			minDist = d
+			closest = t
+		}
+	}
+	return closest
+}
+
 func (a *Animal) Act(env *Environment) {
 	a.stepsInState++
 	currentPos := a.GetSprite().Position
 
+	// La faim augmente à chaque tick (plus vite par grand froid), un animal affamé dépérit
+	a.hunger++
+	if a.cold {
+		a.hunger++
+	}
+	if a.hunger >= a.typ.diet().maxHunger {
+		a.hunger = a.typ.diet().maxHunger
+		a.IsAttacked(1)
+		if !a.IsAlive() {
+			return
+		}
+	}
+	a.breed(env)
+	defer a.updateSignal(currentPos)
+
+	speed := AnimalSpeed
+	if a.energy == 0 {
+		speed *= TiredSpeedFactor
+	}
+
 	switch a.state {
 	case AnimalStateFlee:
-		var fleeX, fleeY float64
-		for _, threat := range a.detectedThreats {
-			tPos := threat.GetSprite().Position
-			fleeX += (currentPos.X - tPos.X)
-			fleeY += (currentPos.Y - tPos.Y)
-		}
-		length := math.Sqrt(fleeX*fleeX + fleeY*fleeY)
-		if length > 0 {
-			dx := (fleeX / length) * AnimalSpeed * 1.5
-			dy := (fleeY / length) * AnimalSpeed * 1.5
-			a.Move(dx, dy, env)
-		}
+		flee := a.fleeVector()
+		a.Move(flee.X*speed*1.5, flee.Y*speed*1.5, env)
+		a.spendEnergy(3)
 
 	case AnimalStateWander:
+		a.recoverEnergy(1)
 		dist := currentPos.DistanceTo(a.targetPos)
-		if dist < 5.0 || a.stepsInState > WanderDuration {
+		if a.food != nil {
+			a.targetPos = a.food.GetSprite().Position
+		} else if a.leader != nil {
+			// Les suiveurs restent avec le troupeau
+			v := a.boidsVector()
+			length := math.Sqrt(v.X*v.X + v.Y*v.Y)
+			if length > speed {
+				v.X, v.Y = v.X/length*speed, v.Y/length*speed
+			}
+			a.Move(v.X, v.Y, env)
+			break
+		} else if dist < 5.0 || a.stepsInState > WanderDuration {
 			a.stepsInState = 0
 			a.targetPos = Position{
 				X: rand.Float64() * float64(env.width),
 				Y: rand.Float64() * float64(env.height),
 			}
+			// Migration saisonnière : la destination est tirée dans la zone de la saison
+			if m := env.season().Migration; m >= 0 {
+				a.targetPos.Y = (m + (rand.Float64()-0.5)*0.3) * float64(env.height)
+			}
+			if a.pasture != nil {
+				a.targetPos = a.pasture.GetSprite().Position
+			}
 		}
 		dx := a.targetPos.X - currentPos.X
 		dy := a.targetPos.Y - currentPos.Y
 		length := math.Sqrt(dx*dx + dy*dy)
 		if length > 0 {
-			dx = (dx / length) * AnimalSpeed
-			dy = (dy / length) * AnimalSpeed
+			dx = (dx / length) * speed
+			dy = (dy / length) * speed
 			a.Move(dx, dy, env)
 		}
 	case AnimalStateStay:
+		a.recoverEnergy(2)
+		a.grazeSteps++
+		if a.grazeSteps >= GrazeDuration {
+			// Un humain ou un autre animal a pu la manger entre-temps
+			if a.food != nil && a.food.Consume() {
+				a.eat(a.food.GetHungerValue() * GrazeFactor)
+			}
+			a.food = nil
+			a.grazeSteps = 0
+			a.state = AnimalStateWander
+		}
+
+	case AnimalStateCharge:
+		tPos := a.chargeTarget.GetSprite().Position
+		if currentPos.DistanceTo(tPos) <= ActionRange*2 {
+			a.strike(a.chargeTarget, a.typ.combat().attack, env)
+			a.chargeTarget = nil
+			a.state = AnimalStateFlee
+			return
+		}
+		dx := tPos.X - currentPos.X
+		dy := tPos.Y - currentPos.Y
+		length := math.Sqrt(dx*dx + dy*dy)
+		if length > 0 {
+			dx = (dx / length) * AnimalSpeed * ChargeSpeedFactor
+			dy = (dy / length) * AnimalSpeed * ChargeSpeedFactor
+			a.Move(dx, dy, env)
+		}
+	}
+}
+
+func (a *Animal) eat(val uint) {
+	if a.hunger < val {
+		a.hunger = 0
+	} else {
+		a.hunger -= val
+	}
+}
+
+func (a *Animal) spendEnergy(cost uint) {
+	if a.energy < cost {
+		a.energy = 0
+	} else {
+		a.energy -= cost
 	}
-}
\ No newline at end of file
+}
+
+func (a *Animal) recoverEnergy(val uint) {
+	a.energy += val
+	if a.energy > AnimalMaxEnergy {
+		a.energy = AnimalMaxEnergy
+	}
+}
@@ -0,0 +1,398 @@
+package simulation
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	TradeLot       = 10    // quantité demandée à chaque troc
+	TradeMinLot    = 3     // on se contente d'un plus petit lot si le partenaire n'a pas mieux
+	TradeMinGain   = 1.05  // on ne troque que si l'on y gagne assez
+	TradeUtility   = 150.0 // utilité d'un troc qui double la valeur de ce que l'on donne
+	MaxTradeRounds = 4
+	TradeTimeout   = 60 // ticks avant d'abandonner une négociation sans réponse
+	TradeCooldown  = 150
+	TradeRange     = ActionRange * 2
+	TrustTrade     = 0.05
+	ToolWorth      = 30.0  // valeur d'un outil neuf, en unités de nourriture de valeur 1
+	ToolTradeBase  = 180.0 // utilité d'acheter un outil que l'on ne peut pas fabriquer
+	ToolScarcity   = 2.0   // un outil vaut plus pour qui ne peut pas le fabriquer
+)
+
+// TradeOffer : Amount unités de Want contre des unités de Give (prix dans Message.Value).
+// Pour l'achat d'un outil, Want est ignoré : on échange l'outil du vendeur (usure Wear) contre de la nourriture.
+type TradeOffer struct {
+	Give    ItemKind
+	Want    ItemKind
+	Amount  uint
+	Round   int
+	Counter bool // contre-offre du vendeur
+
+	ForTool bool
+	Tool    ToolKind
+	Wear    int
+}
+
+// bargaining : style de négociation selon le profil
+// (ouverture par rapport à son prix limite, concession par tour)
+func bargaining(p Profile) (open, markup, concession float64) {
+	switch p {
+	case Selfish:
+		return 0.5, 1.6, 0.1
+	case Pragmatic:
+		return 0.7, 1.3, 0.15
+	case Cautious:
+		return 0.8, 1.2, 0.1
+	default:
+		return 0.9, 1.1, 0.2
+	}
+}
+
+// itemValue : valeur d'une unité pour h, selon sa faim, son énergie et ce qu'il possède déjà
+func (h *Human) itemValue(kind ItemKind) float64 {
+	value := 1 + float64(h.hunger)/MaxHunger
+	if kind == MeatItem || kind == CookedMeatItem {
+		// La viande redonne des forces : précieuse quand on est épuisé
+		value += 0.5 * (1 - float64(h.energy)/MaxEnergy)
+	}
+	held := float64(h.holding(kind))
+	return value * (1 + TradeLot/(TradeLot+held))
+}
+
+// toolValue : valeur d'un outil selon son usure, le goût du profil pour l'investissement
+// et la possibilité d'en fabriquer un soi-même
+func (h *Human) toolValue(t ToolKind, wear int) float64 {
+	value := ToolWorth * investment(h.profile) * float64(wear) / float64(t.durability())
+	if !h.canCraft(t) {
+		value *= ToolScarcity
+	}
+	return value
+}
+
+// limit : prix limite de h pour l'offre (unités de Give par unité de Want)
+func (h *Human) limit(o TradeOffer) float64 {
+	if o.ForTool {
+		return h.toolValue(o.Tool, o.Wear) / h.itemValue(o.Give)
+	}
+	return h.itemValue(o.Want) / h.itemValue(o.Give)
+}
+
+// canSell : le vendeur a de quoi honorer l'offre (l'outil proposé n'a pas changé d'usure)
+func (h *Human) canSell(o TradeOffer) bool {
+	if o.ForTool {
+		return o.Wear > 0 && h.GetTools()[o.Tool] == o.Wear
+	}
+	return h.holding(o.Want) >= o.Amount
+}
+
+// bid : prix proposé par l'acheteur au tour donné (unités de Give par unité de Want)
+func (h *Human) bid(o TradeOffer) float64 {
+	limit := h.limit(o)
+	open, _, concession := bargaining(h.profile)
+	return limit * math.Min(1, open+concession*float64(o.Round))
+}
+
+// ask : prix demandé par le vendeur au tour donné, dans la même unité que bid
+func (h *Human) ask(o TradeOffer) float64 {
+	limit := h.limit(o)
+	_, markup, concession := bargaining(h.profile)
+	return limit * math.Max(1, markup-concession*float64(o.Round))
+}
+
+// tradeLog : trocs du tick en cours (remis à zéro par RecordStats)
+type tradeLog struct {
+	trades     int
+	failed     int
+	volume     uint
+	tools      int
+	priceSum   [ItemKinds]float64
+	priceCount [ItemKinds]int
+	mutex      sync.Mutex
+}
+
+func (t *tradeLog) record(o TradeOffer, price float64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.trades++
+	t.volume += o.Amount + uint(math.Round(price*float64(o.Amount)))
+	if o.ForTool {
+		t.tools++
+		return
+	}
+
+	// Prix exprimés en plantes
+	switch {
+	case o.Give == PlantItem:
+		t.priceSum[o.Want] += price
+		t.priceCount[o.Want]++
+	case o.Want == PlantItem:
+		t.priceSum[o.Give] += 1 / price
+		t.priceCount[o.Give]++
+	}
+}
+
+func (t *tradeLog) fail() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.failed++
+}
+
+// TradeStats : trocs conclus et échoués du tick, volume échangé, outils vendus et prix moyen de chaque objet en plantes
+type TradeStats struct {
+	Trades int
+	Failed int
+	Volume uint
+	Tools  int
+	Prices [ItemKinds]float64 // 0 si aucun échange ce tick
+}
+
+func (t *tradeLog) flush() TradeStats {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	stats := TradeStats{Trades: t.trades, Failed: t.failed, Volume: t.volume, Tools: t.tools}
+	for k := range t.priceSum {
+		if t.priceCount[k] > 0 {
+			stats.Prices[k] = t.priceSum[k] / float64(t.priceCount[k])
+		}
+	}
+	t.trades, t.failed, t.volume, t.tools = 0, 0, 0, 0
+	t.priceSum, t.priceCount = [ItemKinds]float64{}, [ItemKinds]int{}
+	return stats
+}
+
+// exchange : le vendeur donne Amount de Want, l'acheteur paie au prix convenu.
+// Le troc échoue si l'un des deux sacs ne peut pas contenir ce qu'il reçoit.
+// Chacun range ce qu'il a reçu à sa prochaine perception, depuis sa propre goroutine.
+func exchange(buyer, seller *Human, o TradeOffer, price float64, env *Environment) bool {
+	pay := uint(math.Round(price * float64(o.Amount)))
+	if pay == 0 || buyer.holding(o.Give) < pay || !seller.canSell(o) {
+		return false
+	}
+	if o.ForTool {
+		return exchangeTool(buyer, seller, o, price, pay, env)
+	}
+	if buyer.freeSpace()+pay < o.Amount || seller.freeSpace()+o.Amount < pay {
+		return false
+	}
+
+	goods := seller.takeItem(o.Want, o.Amount)
+	paid := buyer.takeItem(o.Give, pay)
+	if goods < o.Amount || paid < pay {
+		// Un autre échange est passé entre-temps : chacun reprend son bien
+		seller.carry(o.Want, goods)
+		buyer.carry(o.Give, paid)
+		return false
+	}
+	buyer.receive(o.Want, goods)
+	seller.receive(o.Give, paid)
+	buyer.adjustTrust(seller.GetID(), TrustTrade)
+	seller.adjustTrust(buyer.GetID(), TrustTrade)
+	env.trades.record(o, price)
+	return true
+}
+
+// exchangeTool : l'outil passe du vendeur à l'acheteur, qui paie en nourriture
+func exchangeTool(buyer, seller *Human, o TradeOffer, price float64, pay uint, env *Environment) bool {
+	if seller.freeSpace() < pay {
+		return false
+	}
+	paid := buyer.takeItem(o.Give, pay)
+	seller.bag.mutex.Lock()
+	wear := seller.bag.tools[o.Tool]
+	if paid < pay || wear != o.Wear {
+		seller.bag.mutex.Unlock()
+		buyer.carry(o.Give, paid)
+		return false
+	}
+	seller.bag.tools[o.Tool] = 0
+	seller.bag.mutex.Unlock()
+
+	buyer.bag.mutex.Lock()
+	buyer.bag.tools[o.Tool] = wear
+	buyer.bag.mutex.Unlock()
+	seller.receive(o.Give, paid)
+
+	buyer.adjustTrust(seller.GetID(), TrustTrade)
+	seller.adjustTrust(buyer.GetID(), TrustTrade)
+	env.trades.record(o, price)
+	env.LogEvent(EventCraft, "%s achete un outil a %s : %s", buyer.GetName(), seller.GetName(), o.Tool)
+	return true
+}
+
+func (h *Human) replyTrade(to uint, perf Performative, o TradeOffer, price float64) {
+	msg := CreateMessage(h.GetID(), to, perf, TopicTrade, to, h.GetSprite().Position)
+	msg.Offer = o
+	msg.Value = price
+	h.Send(msg)
+}
+
+// answerTrades : le vendeur répond aux offres, l'acheteur aux contre-offres
+func (h *Human) answerTrades(env *Environment) {
+	for _, msg := range h.inbox {
+		if msg.Topic != TopicTrade {
+			continue
+		}
+		o := msg.Offer
+		other, ok := env.findAgent(msg.From).(*Human)
+		if !ok || !other.IsAlive() {
+			continue
+		}
+		act, buying := h.currentAction.(*TradeAction)
+		buying = buying && act.PartnerID == msg.From
+
+		switch {
+		case buying && msg.Performative == Accept:
+			h.endTrade()
+		case buying && msg.Performative == Reject:
+			env.trades.fail()
+			h.endTrade()
+		case buying && msg.Performative == Propose && o.Counter:
+			// Contre-offre du vendeur : acceptée si elle reste sous notre prix limite
+			if msg.Value <= h.limit(o) {
+				if exchange(h, other, o, msg.Value, env) {
+					h.replyTrade(msg.From, Accept, o, msg.Value)
+				} else {
+					h.replyTrade(msg.From, Reject, o, 0)
+					env.trades.fail()
+				}
+				h.endTrade()
+			} else if o.Round+1 >= MaxTradeRounds {
+				h.replyTrade(msg.From, Reject, o, 0)
+				env.trades.fail()
+				h.endTrade()
+			} else {
+				o.Round++
+				o.Counter = false
+				h.replyTrade(msg.From, Propose, o, h.bid(o))
+				act.Round = o.Round
+			}
+		case msg.Performative == Propose && !o.Counter:
+			if !h.canSell(o) {
+				h.replyTrade(msg.From, Reject, o, 0)
+				continue
+			}
+			ask := h.ask(o)
+			if msg.Value >= ask && exchange(other, h, o, msg.Value, env) {
+				h.replyTrade(msg.From, Accept, o, msg.Value)
+			} else if msg.Value >= ask || o.Round+1 >= MaxTradeRounds {
+				// Échange impossible ou dernier tour sans accord
+				h.replyTrade(msg.From, Reject, o, 0)
+			} else {
+				o.Counter = true
+				h.replyTrade(msg.From, Propose, o, ask)
+			}
+		}
+	}
+}
+
+func (h *Human) endTrade() {
+	h.currentAction = nil
+	h.tradeCooldown = TradeCooldown
+}
+
+// TradeAction : rejoindre un membre de la tribu et négocier un troc sur quelques tours
+type TradeAction struct {
+	PartnerID uint
+	Offer     TradeOffer
+	Round     int
+
+	sent bool
+}
+
+func (t *TradeAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	partner, ok := env.findAgent(t.PartnerID).(*Human)
+	if !ok || !partner.IsAlive() || h.actionDuration > TradeTimeout {
+		if t.sent {
+			env.trades.fail()
+		}
+		h.endTrade()
+		return
+	}
+	if t.sent {
+		return
+	}
+	if h.GetSprite().Position.DistanceTo(partner.GetSprite().Position) > TradeRange {
+		moveTowards(a, partner.GetSprite().Position, env)
+		return
+	}
+	h.replyTrade(t.PartnerID, Propose, t.Offer, h.bid(t.Offer))
+	t.sent = true
+}
+
+func (t *TradeAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.tradeCooldown > 0 {
+		return 0.0
+	}
+
+	best := 0.0
+	for give := ItemKind(0); give < ItemKinds; give++ {
+		held := h.holding(give)
+		if held < TradeMinLot {
+			continue
+		}
+		for want := ItemKind(0); want < ItemKinds; want++ {
+			if want == give {
+				continue
+			}
+			gain := h.itemValue(want) / h.itemValue(give)
+			if gain < TradeMinGain {
+				continue
+			}
+
+			// Partenaire : un membre de confiance qui a ce qu'on cherche et qui y tient moins que nous
+			for _, ag := range h.visibleAgents {
+				other, ok := ag.(*Human)
+				if !ok || !other.IsAlive() || !h.sameTribe(other) || h.GetTrust(other.GetID()) < DistrustThreshold {
+					continue
+				}
+				o := TradeOffer{Give: give, Want: want, Amount: uint(math.Min(TradeLot, float64(other.holding(want))))}
+				if o.Amount < TradeMinLot || float64(held) < gain*float64(o.Amount) || other.limit(o) >= h.limit(o) {
+					continue
+				}
+				dist := h.GetSprite().Position.DistanceTo(other.GetSprite().Position)
+				utility := TradeUtility*(gain-1) - dist*0.1
+				if utility > best {
+					best = utility
+					t.PartnerID = other.GetID()
+					t.Offer = o
+				}
+			}
+		}
+	}
+
+	// Acheter un outil voulu que l'on ne peut pas fabriquer soi-même
+	for tool := ToolKind(0); tool < ToolKinds; tool++ {
+		if h.GetLifeStage() == Child || !h.wantsTool(tool) || h.canCraft(tool) {
+			continue
+		}
+		for _, ag := range h.visibleAgents {
+			other, ok := ag.(*Human)
+			if !ok || !other.IsAlive() || !h.sameTribe(other) || h.GetTrust(other.GetID()) < DistrustThreshold {
+				continue
+			}
+			o := TradeOffer{Amount: 1, ForTool: true, Tool: tool, Wear: other.GetTools()[tool]}
+			if !other.canSell(o) {
+				continue
+			}
+			// On paie avec une nourriture dont on a assez pour notre prix limite
+			for give := ItemKind(0); give < ItemKinds; give++ {
+				o.Give = give
+				if float64(h.holding(give)) < h.limit(o) {
+					continue
+				}
+				dist := h.GetSprite().Position.DistanceTo(other.GetSprite().Position)
+				utility := ToolTradeBase*investment(h.profile) - dist*0.1
+				if utility > best {
+					best = utility
+					t.PartnerID = other.GetID()
+					t.Offer = o
+				}
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,91 @@
+package simulation
+
+const (
+	DayLength  = 600
+	SeasonDays = 5
+)
+
+type Season int
+
+const (
+	Spring Season = iota
+	Summer
+	Autumn
+	Winter
+	SeasonCount
+)
+
+func (s Season) String() string {
+	switch s {
+	case Spring:
+		return "Printemps"
+	case Summer:
+		return "Ete"
+	case Autumn:
+		return "Automne"
+	default:
+		return "Hiver"
+	}
+}
+
+// SeasonParams : facteurs multiplicatifs appliqués pendant une saison
+type SeasonParams struct {
+	PlantSpawn  float64
+	PlantGrowth float64 // vitesse de pousse et valeur nutritive
+	AnimalSpawn float64
+
+	// Zone de migration des animaux (fraction de la hauteur, négatif = pas de migration)
+	Migration float64
+}
+
+func DefaultSeasons() [SeasonCount]SeasonParams {
+	return [SeasonCount]SeasonParams{
+		Spring: {PlantSpawn: 1.2, PlantGrowth: 1.0, AnimalSpawn: 1.2, Migration: -1},
+		Summer: {PlantSpawn: 1.0, PlantGrowth: 1.2, AnimalSpawn: 1.0, Migration: 0.25},
+		Autumn: {PlantSpawn: 0.6, PlantGrowth: 0.8, AnimalSpawn: 0.7, Migration: -1},
+		Winter: {PlantSpawn: 0.1, PlantGrowth: 0.3, AnimalSpawn: 0.3, Migration: 0.75},
+	}
+}
+
+// Calendar convertit les ticks en jours et en saisons
+type Calendar struct {
+	SeasonDays int
+	Seasons    [SeasonCount]SeasonParams
+}
+
+func CreateCalendar() Calendar {
+	return Calendar{SeasonDays: SeasonDays, Seasons: DefaultSeasons()}
+}
+
+// Day commence à 1
+func (c Calendar) Day(tick int) int {
+	return tick/DayLength + 1
+}
+
+func (c Calendar) Season(tick int) Season {
+	if c.SeasonDays <= 0 {
+		return Spring
+	}
+	return Season((tick / DayLength / c.SeasonDays) % int(SeasonCount))
+}
+
+func (c Calendar) Params(tick int) SeasonParams {
+	return c.Seasons[c.Season(tick)]
+}
+
+// SetCalendar règle la durée des saisons et leurs paramètres
+func (s *Simulation) SetCalendar(seasonDays int, seasons [SeasonCount]SeasonParams) {
+	s.environment.calendar = Calendar{SeasonDays: seasonDays, Seasons: seasons}
+}
+
+func (s *Simulation) GetDay() int {
+	return s.environment.calendar.Day(s.currentStep)
+}
+
+func (s *Simulation) GetSeason() Season {
+	return s.environment.calendar.Season(s.currentStep)
+}
+
+func (e *Environment) season() SeasonParams {
+	return e.calendar.Params(e.tick)
+}
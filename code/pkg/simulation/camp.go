@@ -0,0 +1,414 @@
+package simulation
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	CampSize         = 30
+	CampRadius       = 60.0 // distance à laquelle on profite de l'abri
+	MaxShelter       = 100.0
+	ShelterThreshold = 50.0  // solidité à partir de laquelle l'abri protège
+	BuildRate        = 0.5   // solidité gagnée par tick de construction
+	ShelterDecay     = 0.005 // l'abri s'use s'il n'est pas entretenu
+	BuildEnergyCost  = 1     // énergie dépensée tous les 10 ticks de construction
+	FoundUtility     = 120.0
+	DepositBase      = 150.0 // utilité de remplir une réserve vide
+	StockTarget      = 500   // au-delà, la réserve est jugée suffisante
+)
+
+// Camp : campement d'une tribu, avec un abri à construire et une réserve commune de nourriture
+type Camp struct {
+	ObjectParams
+	tribe   int
+	shelter float64
+	stock   [ItemKinds]uint // réserve par sorte de nourriture
+	mutex   sync.Mutex
+}
+
+func CreateCamp(id uint, tribe int, x, y float64) *Camp {
+	return &Camp{
+		ObjectParams: ObjectParams{
+			id:     id,
+			name:   "Camp",
+			alive:  true,
+			sprite: CreateSprite(x, y, CampSize, CampSize),
+		},
+		tribe: tribe,
+	}
+}
+
+func (c *Camp) GetTribe() int {
+	return c.tribe
+}
+
+func (c *Camp) GetShelter() float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.shelter
+}
+
+// GetStock renvoie la quantité totale de nourriture en réserve
+func (c *Camp) GetStock() uint {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	total := uint(0)
+	for _, n := range c.stock {
+		total += n
+	}
+	return total
+}
+
+// IsSheltered : l'abri est assez solide pour protéger
+func (c *Camp) IsSheltered() bool {
+	return c.GetShelter() >= ShelterThreshold
+}
+
+func (c *Camp) build(amount float64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.shelter = math.Min(MaxShelter, c.shelter+amount)
+}
+
+func (c *Camp) deposit(kind ItemKind, amount uint) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stock[kind] += amount
+}
+
+// withdraw retire au plus amount de la réserve, plantes d'abord, et renvoie la quantité obtenue de chaque sorte
+func (c *Camp) withdraw(amount uint) [ItemKinds]uint {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var taken [ItemKinds]uint
+	for k := range c.stock {
+		part := amount
+		if part > c.stock[k] {
+			part = c.stock[k]
+		}
+		c.stock[k] -= part
+		taken[k] = part
+		amount -= part
+	}
+	return taken
+}
+
+// Decay est appelé à chaque tick par la simulation
+func (c *Camp) Decay() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.shelter = math.Max(0, c.shelter-ShelterDecay)
+}
+
+// camp renvoie le campement de la tribu (nil si elle n'en a pas encore)
+func (e *Environment) camp(tribe int) *Camp {
+	e.campMutex.Lock()
+	defer e.campMutex.Unlock()
+	return e.camps[tribe]
+}
+
+// foundCamp installe le campement de la tribu, sauf si un autre membre l'a déjà fait
+func (e *Environment) foundCamp(tribe int, x, y float64) *Camp {
+	e.campMutex.Lock()
+	defer e.campMutex.Unlock()
+	if e.camps[tribe] != nil {
+		return e.camps[tribe]
+	}
+	c := CreateCamp(e.newID(), tribe, x, y)
+	e.camps[tribe] = c
+	e.AddObject(c)
+	return c
+}
+
+// CampPlacement : campement placé par le scénario plutôt que fondé par la tribu
+type CampPlacement struct {
+	Tribe int     `json:"tribe"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+}
+
+// PlaceCamp installe le campement d'une tribu à une position donnée (avant le lancement)
+func (s *Simulation) PlaceCamp(tribe int, x, y float64) {
+	if tribe < 0 || tribe >= MaxTribes {
+		return
+	}
+	s.environment.foundCamp(tribe, x, y)
+}
+
+// GetCamps renvoie les campements existants
+func (s *Simulation) GetCamps() []*Camp {
+	camps := []*Camp{}
+	for t := 0; t < MaxTribes; t++ {
+		if c := s.environment.camp(t); c != nil {
+			camps = append(camps, c)
+		}
+	}
+	return camps
+}
+
+// perceptCamp repère le campement de la tribu et si l'on est à l'abri
+func (h *Human) perceptCamp(env *Environment) {
+	h.camp = env.camp(h.tribe)
+	h.sheltered = h.camp != nil && h.camp.IsSheltered() &&
+		h.GetSprite().Position.DistanceTo(h.camp.GetSprite().Position) <= CampRadius
+}
+
+// buildWeight : envie de construire l'abri selon le profil
+func buildWeight(p Profile) float64 {
+	switch p {
+	case Collectivist:
+		return 2.0
+	case Cautious:
+		return 1.5
+	case Selfish:
+		return 0.3
+	default:
+		return 1.0
+	}
+}
+
+// depositWeight : envie d'alimenter la réserve commune (l'Égoïste n'y contribue jamais)
+func depositWeight(p Profile) float64 {
+	switch p {
+	case Collectivist:
+		return 1.5
+	case Cautious:
+		return 1.0
+	case Pragmatic:
+		return 0.6
+	default:
+		return 0.0
+	}
+}
+
+// BuildAction : fonder le campement de la tribu puis renforcer son abri
+type BuildAction struct{}
+
+func (b *BuildAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	camp := env.camp(h.tribe)
+	if camp == nil {
+		pos := h.GetSprite().Position
+		env.foundCamp(h.tribe, pos.X, pos.Y)
+		h.currentAction = nil
+		return
+	}
+	if camp.GetShelter() >= MaxShelter {
+		h.currentAction = nil
+		return
+	}
+	if !moveTowards(a, camp.GetSprite().Position, env) {
+		return
+	}
+
+	camp.build(BuildRate)
+	if h.actionDuration%10 == 0 {
+		if h.energy >= BuildEnergyCost {
+			h.energy -= BuildEnergyCost
+		} else {
+			h.currentAction = nil
+		}
+	}
+}
+
+func (b *BuildAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.GetLifeStage() == Child || h.hunger > HungryThreshold {
+		return 0.0
+	}
+
+	if h.camp == nil {
+		return FoundUtility * buildWeight(h.profile)
+	}
+
+	missing := MaxShelter - h.camp.GetShelter()
+	if missing <= 0 {
+		return 0.0
+	}
+	dist := h.GetSprite().Position.DistanceTo(h.camp.GetSprite().Position)
+
+	// Le froid rend l'abri urgent
+	if h.cold {
+		missing *= 2
+	}
+	utility := missing*buildWeight(h.profile) - float64(h.hunger)*0.5 - dist*0.1
+	return math.Max(0.0, utility)
+}
+
+// DepositAction : rapporter de la nourriture à la réserve du campement
+type DepositAction struct {
+	SourceID uint
+
+	carried [ItemKinds]uint
+	loaded  bool
+}
+
+func (d *DepositAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	camp := env.camp(h.tribe)
+	if camp == nil {
+		h.currentAction = nil
+		return
+	}
+
+	h.hunger += HungerCost
+	if h.hunger > MaxHunger {
+		h.hunger = MaxHunger
+	}
+
+	// Rapporter le contenu de son sac
+	if !d.loaded && d.SourceID == 0 {
+		d.loaded = true
+	}
+
+	if !d.loaded {
+		source := env.findObject(d.SourceID)
+		if source == nil || !source.IsAlive() {
+			h.currentAction = nil
+			return
+		}
+		if !moveTowards(a, source.GetSprite().Position, env) {
+			return
+		}
+		switch food := source.(type) {
+		case *Vegetable:
+			value := food.GetHungerValue()
+			if food.Consume() {
+				d.carried[PlantItem] = value
+			}
+		case *Carcass:
+			d.carried[food.GetKind()] = food.Take(food.GetMeat())
+		}
+		d.loaded = true
+		if d.carried == [ItemKinds]uint{} {
+			h.currentAction = nil
+		}
+		return
+	}
+
+	if moveTowards(a, camp.GetSprite().Position, env) {
+		if d.SourceID == 0 {
+			for k := ItemKind(0); k < ItemKinds; k++ {
+				d.carried[k] = h.takeItem(k, h.holding(k))
+			}
+		}
+		for k, n := range d.carried {
+			camp.deposit(ItemKind(k), n)
+		}
+		d.carried = [ItemKinds]uint{}
+		h.currentAction = nil
+	}
+}
+
+func (d *DepositAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	weight := depositWeight(h.profile)
+	if h.camp == nil || weight == 0 || h.GetLifeStage() == Child || h.hunger > HungryThreshold/2 {
+		return 0.0
+	}
+
+	// Plante mûre ou carcasse de sa propre chasse, au plus court vers le campement
+	var source Object
+	minDist := math.Inf(1)
+	cPos := h.camp.GetSprite().Position
+	for _, obj := range h.visibleObjects {
+		switch food := obj.(type) {
+		case *Vegetable:
+			if !food.IsAlive() || food.GetStage() != Ripe {
+				continue
+			}
+		case *Carcass:
+			if !food.IsAlive() || food.GetMeat() == 0 || !food.IsHunter(h.GetID()) {
+				continue
+			}
+		default:
+			continue
+		}
+		dist := h.GetSprite().Position.DistanceTo(obj.GetSprite().Position) + obj.GetSprite().Position.DistanceTo(cPos)
+		if dist < minDist {
+			minDist = dist
+			source = obj
+		}
+	}
+	// Un sac bien rempli se vide directement au campement
+	d.SourceID = 0
+	if load := h.load(); load >= InventoryCapacity/4 {
+		minDist = h.GetSprite().Position.DistanceTo(cPos)
+	} else if source != nil {
+		d.SourceID = source.GetID()
+	} else {
+		return 0.0
+	}
+
+	need := DepositBase * (1 - math.Min(1, float64(h.camp.GetStock())/StockTarget))
+	utility := need*weight - float64(h.hunger)*0.5 - minDist*0.1
+	return math.Max(0.0, utility)
+}
+
+// WithdrawAction : se nourrir dans la réserve du campement
+type WithdrawAction struct{}
+
+func (w *WithdrawAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	camp := env.camp(h.tribe)
+	if camp == nil {
+		h.currentAction = nil
+		return
+	}
+	if !moveTowards(a, camp.GetSprite().Position, env) {
+		return
+	}
+
+	// L'Égoïste se sert à satiété et remplit son sac, les autres ne prennent que le nécessaire
+	if h.profile == Selfish {
+		for k, n := range camp.withdraw(h.hunger + h.freeSpace()) {
+			h.collect(ItemKind(k), n)
+		}
+		h.currentAction = nil
+		return
+	}
+	amount := uint(0)
+	if h.hunger > HungryThreshold/2 {
+		amount = h.hunger - HungryThreshold/2
+	}
+	for _, n := range camp.withdraw(amount) {
+		h.eat(n)
+	}
+	h.currentAction = nil
+}
+
+func (w *WithdrawAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.camp == nil || h.camp.GetStock() == 0 {
+		return 0.0
+	}
+
+	threshold := uint(HungryThreshold)
+	factor := 1.0
+	if h.profile == Selfish {
+		threshold = HungryThreshold / 4
+		factor = 1.5
+	}
+	if h.hunger <= threshold {
+		return 0.0
+	}
+
+	dist := h.GetSprite().Position.DistanceTo(h.camp.GetSprite().Position)
+	utility := float64(h.hunger)*factor - dist*0.1
+	return math.Max(0.0, utility)
+}
+
+// campStats : réserve et solidité de l'abri de chaque tribu
+func (e *Environment) campStats() (stock [MaxTribes]int, shelter [MaxTribes]float64) {
+	for t := 0; t < MaxTribes; t++ {
+		if c := e.camp(t); c != nil {
+			stock[t] = int(c.GetStock())
+			shelter[t] = c.GetShelter()
+		}
+	}
+	return stock, shelter
+}
@@ -0,0 +1,167 @@
+package simulation
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	CarcassLifetime = 600
+	HungryThreshold = 200
+)
+
+// Carcass : la viande d'un animal tué, partageable tant qu'elle n'est pas pourrie
+type Carcass struct {
+	ObjectParams
+	meat    uint
+	kind    ItemKind // MeatItem, ou CookedMeatItem si l'animal a été tué près d'un feu
+	hunters map[uint]bool
+	age     int
+	mutex   sync.Mutex
+}
+
+func CreateCarcass(id uint, sprite Sprite, meat uint, hunters []*Human) *Carcass {
+	c := &Carcass{
+		ObjectParams: ObjectParams{
+			id:     id,
+			name:   "Carcass",
+			alive:  true,
+			sprite: sprite,
+		},
+		meat:    meat,
+		kind:    MeatItem,
+		hunters: make(map[uint]bool),
+	}
+	for _, h := range hunters {
+		c.hunters[h.GetID()] = true
+	}
+	return c
+}
+
+func (c *Carcass) GetMeat() uint {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.meat
+}
+
+func (c *Carcass) GetKind() ItemKind {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.kind
+}
+
+// cook : la viande est cuite sur place et nourrit mieux
+func (c *Carcass) cook() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.kind == MeatItem {
+		c.meat = uint(math.Round(float64(c.meat) * CookFactor))
+		c.kind = CookedMeatItem
+	}
+}
+
+func (c *Carcass) IsHunter(id uint) bool {
+	return c.hunters[id]
+}
+
+// Take retire au plus amount de viande et renvoie la quantité obtenue
+func (c *Carcass) Take(amount uint) uint {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if amount > c.meat {
+		amount = c.meat
+	}
+	c.meat -= amount
+	if c.meat == 0 {
+		c.alive = false
+	}
+	return amount
+}
+
+// Rot est appelé à chaque tick par la simulation
+func (c *Carcass) Rot() {
+	c.age++
+	if c.age >= CarcassLifetime {
+		c.mutex.Lock()
+		c.meat = 0
+		c.alive = false
+		c.mutex.Unlock()
+	}
+}
+
+// distributeCarcass partage la viande entre les chasseurs selon leur profil
+func distributeCarcass(c *Carcass, hunters []*Human, damage map[uint]int) {
+	if len(hunters) == 0 {
+		return
+	}
+	base := c.GetMeat() / uint(len(hunters))
+
+	// Les égoïstes se servent en premier, ceux qui n'ont pas frappé en dernier
+	ordered := append([]*Human{}, hunters...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ci, cj := damage[ordered[i].GetID()] > 0, damage[ordered[j].GetID()] > 0
+		if ci != cj {
+			return ci
+		}
+		return servingOrder(ordered[i].profile) < servingOrder(ordered[j].profile)
+	})
+
+	for _, hunter := range ordered {
+		hunter.collect(c.GetKind(), c.Take(shareFor(hunter, base)))
+	}
+}
+
+func servingOrder(p Profile) int {
+	switch p {
+	case Selfish:
+		return 0
+	case Pragmatic:
+		return 1
+	case Cautious:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// shareFor : part prélevée par un chasseur, le reste reste sur la carcasse.
+// Ce qui n'est pas mangé tout de suite part dans le sac ; le Collectiviste et le Pragmatique laissent le surplus aux autres.
+func shareFor(h *Human, base uint) uint {
+	switch h.profile {
+	case Selfish:
+		return base + base/2
+	case Collectivist:
+		if h.hunger < base {
+			return h.hunger
+		}
+		return base
+	case Pragmatic:
+		if h.hunger < base {
+			return h.hunger
+		}
+		return base
+	default:
+		return base
+	}
+}
+
+// giniCoefficient mesure l'inégalité d'une distribution (0 = égalité parfaite)
+func giniCoefficient(values []float64) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+	return (2*weighted)/(float64(n)*sum) - float64(n+1)/float64(n)
+}
@@ -0,0 +1,198 @@
+package simulation
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	FightDamage     = 8
+	FightCooldown   = 15
+	RaidBaseUtility = 60.0
+	IntruderBonus   = 150.0 // un étranger sur notre territoire
+	DefendUtility   = 400.0
+	TrustFight      = 0.3
+	FightRange      = ActionRange * 1.5
+)
+
+// aggression : propension à attaquer les autres tribus selon le profil
+func aggression(p Profile) float64 {
+	switch p {
+	case Selfish:
+		return 1.5
+	case Pragmatic:
+		return 1.0
+	case Collectivist:
+		return 0.5
+	default:
+		return 0.2
+	}
+}
+
+// conflictLog : combats et morts du tick en cours (remis à zéro par RecordStats)
+type conflictLog struct {
+	hits   int
+	deaths int
+	mutex  sync.Mutex
+}
+
+func (c *conflictLog) record(killed bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.hits++
+	if killed {
+		c.deaths++
+	}
+}
+
+func (c *conflictLog) flush() (hits, deaths int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	hits, deaths = c.hits, c.deaths
+	c.hits, c.deaths = 0, 0
+	return
+}
+
+// perceptConflict : qui m'attaque, et qui attaque un membre visible de ma tribu
+func (h *Human) perceptConflict(env *Environment) {
+	h.attacker = nil
+	h.allyAttacker = nil
+	h.territory = &env.territory
+
+	for _, ag := range h.visibleAgents {
+		raider, ok := ag.(*Human)
+		if !ok || h.sameTribe(raider) {
+			continue
+		}
+		fight, ok := raider.currentAction.(*FightAction)
+		if !ok {
+			continue
+		}
+		if fight.TargetID == h.GetID() {
+			h.attacker = raider
+		} else if victim, ok := env.findAgent(fight.TargetID).(*Human); ok && h.sameTribe(victim) {
+			h.allyAttacker = raider
+		}
+	}
+}
+
+// FightAction : attaquer un membre d'une autre tribu (raid) ou riposter à une attaque
+type FightAction struct {
+	TargetID uint
+
+	cooldown int
+}
+
+func (f *FightAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	target, ok := env.findAgent(f.TargetID).(*Human)
+	if !ok || !target.IsAlive() || h.GetSprite().Position.DistanceTo(target.GetSprite().Position) > h.vision*1.5 {
+		h.currentAction = nil
+		return
+	}
+
+	h.hunger += HungerCost
+	if h.hunger > MaxHunger {
+		h.hunger = MaxHunger
+	}
+
+	if f.cooldown > 0 {
+		f.cooldown--
+	}
+	if h.GetSprite().Position.DistanceTo(target.GetSprite().Position) > FightRange {
+		moveTowards(a, target.GetSprite().Position, env)
+		return
+	}
+	if f.cooldown > 0 {
+		return
+	}
+
+	f.cooldown = FightCooldown
+	target.IsAttacked(FightDamage)
+	target.adjustTrust(h.GetID(), -TrustFight)
+	killed := !target.IsAlive()
+	env.conflicts.record(killed)
+	if killed {
+		env.LogEvent(EventDeath, "%s tue par %s (tribu %d)", target.GetName(), h.GetName(), h.tribe+1)
+		h.currentAction = nil
+		return
+	}
+	env.LogEvent(EventInjury, "%s blesse par %s (tribu %d)", target.GetName(), h.GetName(), h.tribe+1)
+
+	// Trop blessé : on abandonne le combat
+	if h.health < retreatHealth(h.profile) {
+		h.currentAction = nil
+	}
+}
+
+func (f *FightAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.GetLifeStage() == Child || h.health < retreatHealth(h.profile) {
+		return 0.0
+	}
+
+	// Défense : riposter, ou secourir un membre de la tribu
+	if h.attacker != nil && h.attacker.IsAlive() && h.profile != Cautious {
+		f.TargetID = h.attacker.GetID()
+		return DefendUtility
+	}
+	if h.allyAttacker != nil && h.allyAttacker.IsAlive() && (h.profile == Collectivist || h.profile == Pragmatic) {
+		f.TargetID = h.allyAttacker.GetID()
+		return DefendUtility * 0.75
+	}
+
+	// Raid : l'étranger le plus vulnérable, surtout s'il est sur notre territoire
+	var target *Human
+	bestScore := 0.0
+	for _, ag := range h.visibleAgents {
+		other, ok := ag.(*Human)
+		if !ok || !other.IsAlive() || h.sameTribe(other) || other.GetLifeStage() == Child {
+			continue
+		}
+		pos := other.GetSprite().Position
+		score := RaidBaseUtility + float64(MaxHealth-other.health) - h.GetSprite().Position.DistanceTo(pos)*0.2
+		if h.territory.ownerAt(pos) == h.tribe {
+			score += IntruderBonus
+		} else if h.profile != Selfish {
+			// Seul l'Égoïste attaque hors de son territoire
+			continue
+		}
+		score += float64(h.alliesNear(pos)-h.defendersNear(other)) * 20
+		if score > bestScore {
+			bestScore = score
+			target = other
+		}
+	}
+	if target == nil {
+		return 0.0
+	}
+	f.TargetID = target.GetID()
+
+	// On ne part pas en raid le ventre vide
+	utility := bestScore*aggression(h.profile) - float64(h.hunger)*0.5
+	return math.Max(0.0, utility)
+}
+
+// alliesNear compte les membres de la tribu de h proches de pos
+func (h *Human) alliesNear(pos Position) int {
+	n := 0
+	for _, ag := range h.visibleAgents {
+		if other, ok := ag.(*Human); ok && other.IsAlive() && h.sameTribe(other) && other.GetSprite().Position.DistanceTo(pos) <= GroupRadius {
+			n++
+		}
+	}
+	return n
+}
+
+// defendersNear compte les membres de la tribu de victim visibles autour d'elle
+func (h *Human) defendersNear(victim *Human) int {
+	n := 0
+	vPos := victim.GetSprite().Position
+	for _, ag := range h.visibleAgents {
+		if other, ok := ag.(*Human); ok && other.IsAlive() && other != victim && victim.sameTribe(other) && other.GetSprite().Position.DistanceTo(vPos) <= GroupRadius {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,303 @@
+package simulation
+
+import "sort"
+
+const (
+	BidWindow          = 5
+	CommitTimeout      = 60
+	ContractCooldown   = 150
+	RendezvousDistance = 120.0
+	ProfileCount       = 4
+)
+
+type ContractState int
+
+const (
+	ContractAnnounced ContractState = iota
+	ContractAwarded
+	ContractStarted
+	ContractFailed
+)
+
+// HuntContract est tenu par le manager (Contract Net Protocol)
+type HuntContract struct {
+	ID       uint
+	TargetID uint
+	Needed   int
+	State    ContractState
+
+	age       int
+	bids      map[uint]float64
+	awarded   map[uint]bool
+	committed map[uint]bool
+	record    ContractRecord
+}
+
+// ContractRecord résume un tour de protocole, indexé par profil
+type ContractRecord struct {
+	Tick           int
+	ContractID     uint
+	ManagerProfile Profile
+	Needed         int
+	Bids           [ProfileCount]int
+	Refusals       [ProfileCount]int
+	Awarded        [ProfileCount]int
+	Started        bool
+}
+
+// openContract : le manager lance un appel d'offres pour la cible
+func (h *Human) openContract(target *Animal, env *Environment) *HuntContract {
+	id := env.openContract(target.GetID(), h.GetID())
+	if id == 0 {
+		return nil
+	}
+
+	c := &HuntContract{
+		ID:        id,
+		TargetID:  target.GetID(),
+		Needed:    target.GetPeopleNeeded(),
+		State:     ContractAnnounced,
+		bids:      make(map[uint]float64),
+		awarded:   make(map[uint]bool),
+		committed: make(map[uint]bool),
+		record: ContractRecord{
+			Tick:           env.tick,
+			ContractID:     id,
+			ManagerProfile: h.profile,
+			Needed:         target.GetPeopleNeeded(),
+		},
+	}
+	h.contract = c
+
+	msg := CreateMessage(h.GetID(), BroadcastID, CallForProposal, TopicHunt, target.GetID(), target.GetSprite().Position)
+	msg.ContractID = id
+	msg.Value = float64(c.Needed)
+	h.Send(msg)
+	return c
+}
+
+// manageContract fait avancer le protocole côté manager
+func (h *Human) manageContract(env *Environment) {
+	c := h.contract
+	if c == nil {
+		return
+	}
+	c.age++
+
+	if act, ok := h.currentAction.(*HuntAction); !ok || act.ContractID != c.ID {
+		h.failContract(c, env)
+		return
+	}
+
+	for _, msg := range h.inbox {
+		if msg.ContractID != c.ID || msg.Topic != TopicHunt {
+			continue
+		}
+		bidder, _ := env.findAgent(msg.From).(*Human)
+
+		switch msg.Performative {
+		case Propose:
+			if c.State == ContractAnnounced {
+				c.bids[msg.From] = msg.Value
+				if bidder != nil {
+					c.record.Bids[bidder.profile]++
+				}
+			}
+		case Reject:
+			if c.State == ContractAnnounced && bidder != nil {
+				c.record.Refusals[bidder.profile]++
+			}
+			if c.awarded[msg.From] {
+				// L'offre était une promesse de participer
+				h.adjustTrust(msg.From, -TrustPromiseBroken)
+				delete(c.awarded, msg.From)
+				delete(c.committed, msg.From)
+				h.awardNext(c, env)
+			}
+		case Inform:
+			if c.awarded[msg.From] && !c.committed[msg.From] {
+				c.committed[msg.From] = true
+				h.adjustTrust(msg.From, TrustPromiseKept)
+			}
+		}
+	}
+
+	switch c.State {
+	case ContractAnnounced:
+		if c.age >= BidWindow {
+			c.State = ContractAwarded
+			h.awardNext(c, env)
+		}
+	case ContractAwarded:
+		if len(c.committed) >= c.Needed-1 {
+			h.startContract(c, env)
+		} else if c.age >= BidWindow+CommitTimeout {
+			h.failContract(c, env)
+		}
+	}
+}
+
+// awardNext attribue les rôles aux meilleures offres restantes
+func (h *Human) awardNext(c *HuntContract, env *Environment) {
+	if c.State != ContractAwarded {
+		return
+	}
+
+	candidates := []uint{}
+	for id := range c.bids {
+		if !c.awarded[id] {
+			candidates = append(candidates, id)
+		}
+	}
+	// Le manager préfère les partenaires en qui il a confiance
+	score := func(id uint) float64 {
+		return c.bids[id] * (0.5 + h.GetTrust(id))
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return score(candidates[i]) > score(candidates[j])
+	})
+
+	for _, id := range candidates {
+		if len(c.awarded) >= c.Needed-1 {
+			break
+		}
+		c.awarded[id] = true
+		delete(c.bids, id)
+		if bidder, ok := env.findAgent(id).(*Human); ok {
+			c.record.Awarded[bidder.profile]++
+		}
+		msg := CreateMessage(h.GetID(), id, Accept, TopicHunt, c.TargetID, h.GetSprite().Position)
+		msg.ContractID = c.ID
+		h.Send(msg)
+	}
+
+	if len(c.awarded) < c.Needed-1 {
+		h.failContract(c, env)
+	}
+}
+
+func (h *Human) startContract(c *HuntContract, env *Environment) {
+	c.State = ContractStarted
+	c.record.Started = true
+	for id := range c.committed {
+		msg := CreateMessage(h.GetID(), id, Inform, TopicHunt, c.TargetID, h.GetSprite().Position)
+		msg.ContractID = c.ID
+		h.Send(msg)
+	}
+	if act, ok := h.currentAction.(*HuntAction); ok && act.ContractID == c.ID {
+		act.started = true
+	}
+	h.closeContract(c, env)
+}
+
+func (h *Human) failContract(c *HuntContract, env *Environment) {
+	c.State = ContractFailed
+	for id := range c.awarded {
+		if !c.committed[id] {
+			h.adjustTrust(id, -TrustPromiseBroken)
+		}
+		msg := CreateMessage(h.GetID(), id, Reject, TopicHunt, c.TargetID, h.GetSprite().Position)
+		msg.ContractID = c.ID
+		h.Send(msg)
+	}
+	for id := range c.bids {
+		msg := CreateMessage(h.GetID(), id, Reject, TopicHunt, c.TargetID, h.GetSprite().Position)
+		msg.ContractID = c.ID
+		h.Send(msg)
+	}
+	if act, ok := h.currentAction.(*HuntAction); ok && act.ContractID == c.ID {
+		h.currentAction = nil
+	}
+	h.contractCooldown = ContractCooldown
+	h.closeContract(c, env)
+}
+
+func (h *Human) closeContract(c *HuntContract, env *Environment) {
+	env.closeContract(c.TargetID, c.record)
+	h.contract = nil
+}
+
+// answerContracts traite les messages du protocole côté participant
+func (h *Human) answerContracts() {
+	for _, msg := range h.inbox {
+		if msg.Topic != TopicHunt || msg.ContractID == 0 {
+			continue
+		}
+
+		switch msg.Performative {
+		case CallForProposal:
+			if h.contract != nil || h.isContractBound() {
+				h.replyContract(msg, Reject, 0)
+				continue
+			}
+			bid := h.computeBid(msg)
+			if bid > 0 {
+				h.replyContract(msg, Propose, bid)
+			} else {
+				h.replyContract(msg, Reject, 0)
+			}
+
+		case Accept:
+			// Rôle attribué : on s'engage si on est encore libre
+			if h.contract != nil || h.isContractBound() {
+				h.replyContract(msg, Reject, 0)
+				continue
+			}
+			h.currentAction = &HuntAction{
+				TargetID:   msg.TargetID,
+				TargetPos:  msg.Pos,
+				ContractID: msg.ContractID,
+			}
+			h.actionDuration = 0
+			h.replyContract(msg, Inform, 0)
+
+		case Inform:
+			if act, ok := h.currentAction.(*HuntAction); ok && act.ContractID == msg.ContractID {
+				act.started = true
+			}
+
+		case Reject:
+			// Annulation par le manager
+			if act, ok := h.currentAction.(*HuntAction); ok && act.ContractID == msg.ContractID {
+				h.currentAction = nil
+			}
+		}
+	}
+}
+
+func (h *Human) replyContract(msg Message, perf Performative, value float64) {
+	reply := CreateMessage(h.GetID(), msg.From, perf, TopicHunt, msg.TargetID, h.GetSprite().Position)
+	reply.ContractID = msg.ContractID
+	reply.Value = value
+	h.Send(reply)
+}
+
+// computeBid : l'offre dépend du profil, de l'énergie, de la faim et de la distance
+func (h *Human) computeBid(msg Message) float64 {
+	if h.GetLifeStage() == Child {
+		return 0
+	}
+	dist := h.GetSprite().Position.DistanceTo(msg.Pos)
+	bid := float64(h.energy)/MaxEnergy*50 + float64(h.hunger)/MaxHunger*100 - dist*0.05
+
+	// On s'investit moins pour un manager peu fiable
+	bid += (h.GetTrust(msg.From) - InitialTrust) * 40
+
+	switch h.profile {
+	case Collectivist:
+		bid += 30
+	case Pragmatic:
+		bid += 10
+	case Selfish:
+		bid -= 20
+	case Cautious:
+		bid -= msg.Value * 10
+	}
+	return bid
+}
+
+// isContractBound : engagé dans une chasse organisée
+func (h *Human) isContractBound() bool {
+	act, ok := h.currentAction.(*HuntAction)
+	return ok && act.ContractID != 0
+}
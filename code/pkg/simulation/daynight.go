@@ -0,0 +1,39 @@
+package simulation
+
+import "math"
+
+const (
+	NightThreshold    = 0.3
+	NightVision       = 0.4
+	MaxSleepDebt      = DayLength
+	SleepWeight       = 0.4
+	NightRestBonus    = 2
+	PredatorDayHunger = PredatorMaxHunger / 2
+)
+
+// Daylight : 0 à minuit, 1 à midi (chaque jour commence à minuit)
+func Daylight(tick int) float64 {
+	t := float64(tick%DayLength) / DayLength
+	return 0.5 - 0.5*math.Cos(2*math.Pi*t)
+}
+
+func (e *Environment) daylight() float64 {
+	return Daylight(e.tick)
+}
+
+func (e *Environment) isNight() bool {
+	return e.daylight() < NightThreshold
+}
+
+// visionFactor : la vue baisse jusqu'à NightVision au milieu de la nuit
+func (e *Environment) visionFactor() float64 {
+	return NightVision + (1-NightVision)*e.daylight()
+}
+
+func (s *Simulation) GetDaylight() float64 {
+	return Daylight(s.currentStep)
+}
+
+func (s *Simulation) IsNight() bool {
+	return s.environment.isNight()
+}
@@ -0,0 +1,247 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+)
+
+const (
+	DroughtSpawnFactor  = 0.05
+	DroughtGrowthFactor = 0.2
+	FloodWidth          = 220.0
+	FloodHeight         = 160.0
+	ColdEnergyDrain     = 2
+	OutbreakRate        = 0.2
+)
+
+type DisasterKind int
+
+const (
+	Drought DisasterKind = iota
+	Flood
+	ColdSnap
+	Outbreak
+	DisasterCount
+)
+
+var disasterKeys = []string{"drought", "flood", "cold", "outbreak"}
+
+func (k DisasterKind) String() string {
+	switch k {
+	case Drought:
+		return "Secheresse"
+	case Flood:
+		return "Inondation"
+	case ColdSnap:
+		return "Vague de froid"
+	default:
+		return "Epidemie"
+	}
+}
+
+// UnmarshalJSON accepte le nom de la catastrophe ("drought", "flood", "cold", "outbreak")
+func (k *DisasterKind) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for i, key := range disasterKeys {
+		if strings.EqualFold(name, key) {
+			*k = DisasterKind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("catastrophe inconnue : %q", name)
+}
+
+// DisasterConfig : une catastrophe programmée (Start > 0) ou aléatoire (Probability par jour)
+type DisasterConfig struct {
+	Kind        DisasterKind `json:"kind"`
+	Probability float64      `json:"probability"`
+	Start       int          `json:"start"`
+	Duration    int          `json:"duration"`
+}
+
+// valid ramène les valeurs du fichier dans leurs bornes
+func (c DisasterConfig) valid() DisasterConfig {
+	c.Probability = math.Max(0, math.Min(1, c.Probability))
+	if c.Start < 0 {
+		c.Start = 0
+	}
+	if c.Duration < 1 {
+		c.Duration = 1
+	}
+	return c
+}
+
+type Scenario struct {
+	Disasters []DisasterConfig `json:"disasters"`
+	Mating    MatingRules      `json:"mating"`
+	Camps     []CampPlacement  `json:"camps"`
+	Infected  int              `json:"infected"` // malades au départ
+}
+
+// DefaultScenario : aucune catastrophe, elles ne viennent que d'un fichier de scénario
+func DefaultScenario() Scenario {
+	return Scenario{Mating: DefaultMatingRules(), Infected: InitialInfected}
+}
+
+// LoadScenario : les règles absentes du fichier gardent leur valeur par défaut
+func LoadScenario(path string) (Scenario, error) {
+	sc := DefaultScenario()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sc, err
+	}
+	err = json.Unmarshal(data, &sc)
+	sc.Mating = sc.Mating.valid()
+	for i, d := range sc.Disasters {
+		sc.Disasters[i] = d.valid()
+	}
+	return sc, err
+}
+
+// Zone : rectangle de la carte (zone inondée)
+type Zone struct {
+	X, Y, W, H float64
+}
+
+func (z Zone) Contains(x, y float64) bool {
+	return x >= z.X && x <= z.X+z.W && y >= z.Y && y <= z.Y+z.H
+}
+
+// Disaster : une catastrophe en cours
+type Disaster struct {
+	Kind  DisasterKind
+	Start int
+	End   int
+	Zone  Zone
+}
+
+func (s *Simulation) SetScenario(sc Scenario) {
+	s.scenario = sc
+	s.environment.mating = sc.Mating
+	for _, c := range sc.Camps {
+		s.PlaceCamp(c.Tribe, c.X, c.Y)
+	}
+}
+
+// GetActiveDisasters renvoie une copie des catastrophes en cours
+func (s *Simulation) GetActiveDisasters() []Disaster {
+	s.environment.mutex.RLock()
+	defer s.environment.mutex.RUnlock()
+	return append([]Disaster{}, s.environment.disasters...)
+}
+
+// updateDisasters est appelé avant le tick : les agents voient l'état du tick
+func (s *Simulation) updateDisasters() {
+	env := &s.environment
+	active := []Disaster{}
+	for _, d := range env.disasters {
+		if s.currentStep < d.End {
+			active = append(active, d)
+		} else {
+			env.LogEvent(EventDisaster, "Fin : %s", d.Kind)
+		}
+	}
+
+	newDay := s.currentStep%DayLength == 0
+	for _, cfg := range s.scenario.Disasters {
+		scheduled := cfg.Start > 0 && cfg.Start == s.currentStep
+		random := cfg.Start == 0 && newDay && rand.Float64() < cfg.Probability
+		if (scheduled || random) && !containsKind(active, cfg.Kind) {
+			active = append(active, s.startDisaster(cfg))
+		}
+	}
+
+	env.mutex.Lock()
+	env.disasters = active
+	env.mutex.Unlock()
+}
+
+func (s *Simulation) startDisaster(cfg DisasterConfig) Disaster {
+	env := &s.environment
+	d := Disaster{Kind: cfg.Kind, Start: s.currentStep, End: s.currentStep + cfg.Duration}
+
+	switch cfg.Kind {
+	case Flood:
+		d.Zone = Zone{
+			X: rand.Float64() * (float64(env.width) - FloodWidth),
+			Y: rand.Float64() * (float64(env.height) - FloodHeight),
+			W: FloodWidth,
+			H: FloodHeight,
+		}
+		// Tout ce qui est au sol dans la zone est emporté
+		for _, o := range env.objects {
+			pos := o.GetSprite().Position
+			if !d.Zone.Contains(pos.X, pos.Y) {
+				continue
+			}
+			switch obj := o.(type) {
+			case *Vegetable:
+				obj.destroy()
+			case *Carcass:
+				obj.Take(obj.GetMeat())
+			case *Fire:
+				obj.extinguish()
+			case *Material:
+				obj.pickUp()
+			}
+		}
+	case Outbreak:
+		// Premiers malades de l'épidémie, la maladie se propage ensuite par contact
+		for _, a := range env.agents {
+			if h, ok := a.(*Human); ok && h.IsAlive() && rand.Float64() < OutbreakRate {
+				h.infect()
+			}
+		}
+	}
+
+	env.LogEvent(EventDisaster, "%s !", d.Kind)
+	return d
+}
+
+func containsKind(disasters []Disaster, kind DisasterKind) bool {
+	for _, d := range disasters {
+		if d.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Environment) hasDisaster(kind DisasterKind) bool {
+	return containsKind(e.disasters, kind)
+}
+
+// isFlooded : une zone inondée est infranchissable
+func (e *Environment) isFlooded(x, y float64) bool {
+	for _, d := range e.disasters {
+		if d.Kind == Flood && d.Zone.Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// weather : paramètres de la saison, modifiés par les catastrophes en cours
+func (e *Environment) weather() SeasonParams {
+	p := e.season()
+	if e.hasDisaster(Drought) {
+		p.PlantSpawn *= DroughtSpawnFactor
+		p.PlantGrowth *= DroughtGrowthFactor
+	}
+	return p
+}
+
+func (s *Simulation) disasterMask() uint8 {
+	var mask uint8
+	for _, d := range s.environment.disasters {
+		mask |= 1 << uint(d.Kind)
+	}
+	return mask
+}
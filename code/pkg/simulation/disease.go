@@ -0,0 +1,231 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+const (
+	InfectionDuration     = 900
+	DiseaseDamageInterval = 20
+	DiseaseEnergyDrain    = 1
+	ContagionRadius       = 60.0
+	HuntTransmission      = 0.03 // par tick de chasse près d'un malade
+	MateTransmission      = 0.5
+	CareTransmission      = 0.005 // par tick de soins
+	CareRecovery          = 2     // ticks de maladie en moins par tick de soins
+	CareHealInterval      = 15
+	CareBaseUtility       = 200.0
+	SickAvoidRadius       = 80.0
+	InitialInfected       = 2 // malades présents dès le départ
+)
+
+// HealthState : modèle SIR (sain, malade, immunisé)
+type HealthState int
+
+const (
+	Susceptible HealthState = iota
+	Infected
+	Recovered
+)
+
+func (s HealthState) String() string {
+	switch s {
+	case Infected:
+		return "Malade"
+	case Recovered:
+		return "Immunise"
+	default:
+		return "Sain"
+	}
+}
+
+// disease : état SIR d'un humain, modifié par les autres humains (contagion, soins)
+type disease struct {
+	mutex     sync.Mutex
+	state     HealthState
+	remaining int
+}
+
+func (h *Human) GetHealthState() HealthState {
+	h.disease.mutex.Lock()
+	defer h.disease.mutex.Unlock()
+	return h.disease.state
+}
+
+func (h *Human) IsInfected() bool {
+	return h.GetHealthState() == Infected
+}
+
+// infect : seul un humain sain peut tomber malade, la guérison immunise
+func (h *Human) infect() bool {
+	h.disease.mutex.Lock()
+	defer h.disease.mutex.Unlock()
+	if h.disease.state != Susceptible {
+		return false
+	}
+	h.disease.state = Infected
+	h.disease.remaining = InfectionDuration
+	return true
+}
+
+// expose : contact avec source, contamination avec la probabilité rate si source est malade
+func (h *Human) expose(source *Human, rate float64) {
+	if source.IsInfected() && rand.Float64() < rate {
+		h.infect()
+	}
+}
+
+// treat : les soins raccourcissent la maladie
+func (h *Human) treat(ticks int) {
+	h.disease.mutex.Lock()
+	defer h.disease.mutex.Unlock()
+	if h.disease.state == Infected {
+		h.disease.remaining -= ticks
+	}
+}
+
+// progressDisease : appelé à chaque tick, le malade perd de la santé jusqu'à sa guérison
+func (h *Human) progressDisease() {
+	h.disease.mutex.Lock()
+	defer h.disease.mutex.Unlock()
+	if h.disease.state != Infected {
+		return
+	}
+	h.disease.remaining--
+	if h.disease.remaining <= 0 {
+		h.disease.state = Recovered
+		return
+	}
+	if h.disease.remaining%DiseaseDamageInterval == 0 {
+		h.IsAttacked(1)
+	}
+}
+
+// catchDisease : contagion entre chasseurs proches pendant une chasse commune
+func (h *Human) catchDisease() {
+	if _, hunting := h.currentAction.(*HuntAction); !hunting {
+		return
+	}
+	for _, ag := range h.visibleAgents {
+		other, ok := ag.(*Human)
+		if !ok || h.GetSprite().Position.DistanceTo(other.GetSprite().Position) > ContagionRadius {
+			continue
+		}
+		if _, ok := other.currentAction.(*HuntAction); ok {
+			h.expose(other, HuntTransmission)
+		}
+	}
+}
+
+// closestSick renvoie le malade visible le plus proche et sa distance
+func (h *Human) closestSick() (*Human, float64) {
+	var closest *Human
+	minDist := math.Inf(1)
+	for _, ag := range h.visibleAgents {
+		if other, ok := ag.(*Human); ok && other.IsAlive() && other.IsInfected() {
+			d := h.GetSprite().Position.DistanceTo(other.GetSprite().Position)
+			if d < minDist {
+				minDist = d
+				closest = other
+			}
+		}
+	}
+	return closest, minDist
+}
+
+// avoidSick : le Prudent s'écarte des malades trop proches
+func (h *Human) avoidSick(env *Environment) {
+	if h.profile != Cautious || h.IsInfected() {
+		return
+	}
+	sick, dist := h.closestSick()
+	if sick == nil || dist > SickAvoidRadius || dist == 0 {
+		return
+	}
+	pos := h.GetSprite().Position
+	sPos := sick.GetSprite().Position
+	h.Move((pos.X-sPos.X)/dist*MoveSpeed/2, (pos.Y-sPos.Y)/dist*MoveSpeed/2, env)
+}
+
+// CareAction : le Collectiviste soigne un malade, au risque d'être contaminé
+type CareAction struct {
+	PatientID uint
+}
+
+func (c *CareAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	patient, ok := env.findAgent(c.PatientID).(*Human)
+	if !ok || !patient.IsAlive() || !patient.IsInfected() {
+		h.currentAction = nil
+		return
+	}
+
+	h.hunger += HungerCost
+	if h.hunger > MaxHunger {
+		h.hunger = MaxHunger
+	}
+
+	if !moveTowards(a, patient.GetSprite().Position, env) {
+		return
+	}
+
+	patient.treat(CareRecovery)
+	if h.actionDuration%CareHealInterval == 0 && patient.health < MaxHealth {
+		patient.health++
+	}
+	h.expose(patient, CareTransmission)
+}
+
+func (c *CareAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.profile != Collectivist || h.IsInfected() {
+		return 0.0
+	}
+
+	// On soigne en priorité le malade le plus faible
+	var patient *Human
+	bestScore := 0.0
+	for _, ag := range h.visibleAgents {
+		if other, ok := ag.(*Human); ok && other.IsAlive() && other.IsInfected() && h.sameTribe(other) {
+			dist := h.GetSprite().Position.DistanceTo(other.GetSprite().Position)
+			score := CareBaseUtility + float64(MaxHealth-other.health)*2 - dist*0.1
+			if score > bestScore {
+				bestScore = score
+				patient = other
+			}
+		}
+	}
+	if patient == nil {
+		return 0.0
+	}
+	c.PatientID = patient.GetID()
+
+	// Un soigneur affamé ou épuisé s'occupe d'abord de lui
+	utility := bestScore - float64(h.hunger)*0.2
+	if h.energy < MaxEnergy/5 {
+		utility *= 0.5
+	}
+	return math.Max(0.0, utility)
+}
+
+// countSIR compte les humains vivants sains, malades et immunisés
+func countSIR(agents []Agent) (s, i, r int) {
+	for _, a := range agents {
+		h, ok := a.(*Human)
+		if !ok || !h.IsAlive() {
+			continue
+		}
+		switch h.GetHealthState() {
+		case Susceptible:
+			s++
+		case Infected:
+			i++
+		case Recovered:
+			r++
+		}
+	}
+	return
+}
@@ -10,23 +10,97 @@ type Environment struct {
 	agents  []Agent
 	objects []Object
 	mutex   sync.RWMutex
+
+	// Compteur d'identifiants, partagé par la simulation et les agents
+	globalIDCounter uint
+	idMutex         sync.Mutex
+
+	// Messages envoyés pendant le tick courant
+	pendingMessages []Message
+	messageMutex    sync.Mutex
+
+	// Contract Net : appels d'offres ouverts (cible -> contrat) et historique
+	tick          int
+	contractSeq   uint
+	openContracts map[uint]openContract
+	contractLog   []ContractRecord
+	contractCount int // appels d'offres clos
+	huntsStarted  int // dont ceux qui ont abouti à une chasse
+	contractMutex sync.Mutex
+
+	eventLog eventLog
+
+	// Les animaux se reproduisent eux-mêmes (voir SetAnimalDynamics), sans dépasser maxAnimals
+	animalBreeding bool
+	maxAnimals     int
+
+	calendar Calendar
+
+	// Catastrophes en cours (modifiées entre deux ticks uniquement)
+	disasters []Disaster
+
+	// Survie des enfants par profil parental
+	families familyStats
+
+	// Règles de reproduction humaine (scénario)
+	mating MatingRules
+
+	// Territoires des tribus et combats du tick
+	territory territory
+	conflicts conflictLog
+
+	// Campement de chaque tribu (nil tant qu'il n'est pas fondé)
+	camps     [MaxTribes]*Camp
+	campMutex sync.Mutex
+	// Trocs du tick, outils fabriqués
+	trades tradeLog
+	tools  toolLog
 }
 
 func CreateEnvironment(width int, height int) Environment {
 	return Environment{
-		width:   width,
-		height:  height,
-		agents:  []Agent{},
-		objects: []Object{},
+		width:         width,
+		height:        height,
+		agents:        []Agent{},
+		objects:       []Object{},
+		openContracts: make(map[uint]openContract),
+		contractLog:   []ContractRecord{},
+		calendar:      CreateCalendar(),
+		territory:     createTerritory(width, height),
 	}
 }
 
+// newID : identifiant unique, utilisable pendant le tick par les agents
+func (e *Environment) newID() uint {
+	e.idMutex.Lock()
+	defer e.idMutex.Unlock()
+	e.globalIDCounter++
+	return e.globalIDCounter
+}
+
 func (e *Environment) AddAgent(agent Agent) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 	e.agents = append(e.agents, agent)
 }
 
+// addYoung ajoute un animal né sur la carte s'il reste de la place sous maxAnimals
+func (e *Environment) addYoung(young *Animal) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	count := 0
+	for _, a := range e.agents {
+		if _, ok := a.(*Animal); ok && a.IsAlive() {
+			count++
+		}
+	}
+	if count >= e.maxAnimals {
+		return false
+	}
+	e.agents = append(e.agents, young)
+	return true
+}
+
 func (e *Environment) AddObject(obj Object) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
@@ -52,6 +126,8 @@ func (e *Environment) RemoveDeadAgents() {
 	for _, a := range e.agents {
 		if a.IsAlive() {
 			newAgents = append(newAgents, a)
+		} else if h, ok := a.(*Human); ok && h.GetLifeStage() == Child {
+			e.families.record(h, func(c *ChildSurvival) { c.Deaths++ })
 		}
 	}
 	e.agents = newAgents
@@ -62,6 +138,10 @@ func (e *Environment) RemoveDeadObjects() {
 	defer e.mutex.Unlock()
 	newObjects := []Object{}
 	for _, o := range e.objects {
+		if veg, ok := o.(*Vegetable); ok && veg.IsDormant() {
+			newObjects = append(newObjects, o)
+			continue
+		}
 		if o.IsAlive() {
 			newObjects = append(newObjects, o)
 		}
@@ -69,6 +149,112 @@ func (e *Environment) RemoveDeadObjects() {
 	e.objects = newObjects
 }
 
+func (e *Environment) findAgent(id uint) Agent {
+	for _, a := range e.agents {
+		if a.GetID() == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (e *Environment) findObject(id uint) Object {
+	for _, o := range e.objects {
+		if o.GetID() == id {
+			return o
+		}
+	}
+	return nil
+}
+
+// PostMessages est appelé par les goroutines des agents
+func (e *Environment) PostMessages(msgs []Message) {
+	e.messageMutex.Lock()
+	defer e.messageMutex.Unlock()
+	e.pendingMessages = append(e.pendingMessages, msgs...)
+}
+
+// DeliverMessages distribue les messages du tick précédent aux humains à portée
+func (e *Environment) DeliverMessages() {
+	e.messageMutex.Lock()
+	msgs := e.pendingMessages
+	e.pendingMessages = nil
+	e.messageMutex.Unlock()
+
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	for _, msg := range msgs {
+		for _, a := range e.agents {
+			if !a.IsAlive() || a.GetID() == msg.From {
+				continue
+			}
+			// Seuls les humains lisent leurs messages : les animaux et les loups n'en reçoivent pas
+			if _, ok := a.(*Human); !ok {
+				continue
+			}
+			if !msg.IsBroadcast() && a.GetID() != msg.To {
+				continue
+			}
+			if msg.origin.DistanceTo(a.GetSprite().Position) > CommunicationRange {
+				continue
+			}
+			a.Receive(msg)
+		}
+	}
+}
+
+type openContract struct {
+	id        uint
+	managerID uint
+	openedAt  int
+}
+
+// openContract réserve la cible, renvoie 0 si un contrat existe déjà
+func (e *Environment) openContract(targetID, managerID uint) uint {
+	e.contractMutex.Lock()
+	defer e.contractMutex.Unlock()
+	if _, exists := e.openContracts[targetID]; exists {
+		return 0
+	}
+	e.contractSeq++
+	e.openContracts[targetID] = openContract{id: e.contractSeq, managerID: managerID, openedAt: e.tick}
+	return e.contractSeq
+}
+
+// expireContracts libère les cibles dont le manager est mort
+func (e *Environment) expireContracts() {
+	e.contractMutex.Lock()
+	defer e.contractMutex.Unlock()
+	for targetID, oc := range e.openContracts {
+		manager := e.findAgent(oc.managerID)
+		if manager == nil || !manager.IsAlive() || e.tick-oc.openedAt > 2*(BidWindow+CommitTimeout) {
+			delete(e.openContracts, targetID)
+		}
+	}
+}
+
+// CooperationRate : part des appels d'offres ayant abouti à une chasse
+func (e *Environment) CooperationRate() float64 {
+	e.contractMutex.Lock()
+	defer e.contractMutex.Unlock()
+	if e.contractCount == 0 {
+		return 0
+	}
+	return float64(e.huntsStarted) / float64(e.contractCount)
+}
+
+func (e *Environment) closeContract(targetID uint, record ContractRecord) {
+	e.contractMutex.Lock()
+	defer e.contractMutex.Unlock()
+	delete(e.openContracts, targetID)
+	e.contractLog = append(e.contractLog, record)
+	e.contractCount++
+	if record.Started {
+		e.huntsStarted++
+	}
+}
+
 // IsPositionInside reste inchangé...
 func (e *Environment) IsPositionInside(s Sprite, dx, dy float64) bool {
 	e.mutex.RLock()
@@ -77,6 +263,11 @@ func (e *Environment) IsPositionInside(s Sprite, dx, dy float64) bool {
 	newX := s.Position.X + dx
 	newY := s.Position.Y + dy
 
+	// On peut sortir d'une zone inondée, pas y entrer
+	if e.isFlooded(newX, newY) && !e.isFlooded(s.Position.X, s.Position.Y) {
+		return false
+	}
+
 	return !(newX < 0 ||
 		newY < 0 ||
 		newX+float64(s.width) > float64(e.width) ||
@@ -108,4 +299,4 @@ func (e *Environment) IsLocationFree(x, y, minDist float64) bool {
 	}
 
 	return true
-}
\ No newline at end of file
+}
@@ -0,0 +1,54 @@
+package simulation
+
+import (
+	"fmt"
+	"sync"
+)
+
+const MaxEvents = 500
+
+type EventKind int
+
+const (
+	EventInjury EventKind = iota
+	EventDeath
+	EventDisaster
+	EventCraft
+)
+
+// Event : une ligne du journal de la simulation
+type Event struct {
+	Tick int
+	Kind EventKind
+	Text string
+}
+
+type eventLog struct {
+	events []Event
+	mutex  sync.Mutex
+}
+
+// LogEvent peut être appelé depuis n'importe quelle goroutine d'agent
+func (e *Environment) LogEvent(kind EventKind, format string, args ...interface{}) {
+	e.eventLog.mutex.Lock()
+	defer e.eventLog.mutex.Unlock()
+	e.eventLog.events = append(e.eventLog.events, Event{
+		Tick: e.tick,
+		Kind: kind,
+		Text: fmt.Sprintf(format, args...),
+	})
+	if len(e.eventLog.events) > MaxEvents {
+		e.eventLog.events = e.eventLog.events[len(e.eventLog.events)-MaxEvents:]
+	}
+}
+
+// GetEvents renvoie les n derniers événements
+func (s *Simulation) GetEvents(n int) []Event {
+	log := &s.environment.eventLog
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	if n > len(log.events) {
+		n = len(log.events)
+	}
+	return append([]Event{}, log.events[len(log.events)-n:]...)
+}
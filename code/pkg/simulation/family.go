@@ -0,0 +1,279 @@
+package simulation
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	FollowDistance  = 40.0
+	FeedUtilityBase = 1.5 // utilité = faim de l'enfant x poids familial x base
+)
+
+// familyWeight : importance accordée à ses enfants selon le profil
+func familyWeight(p Profile) float64 {
+	switch p {
+	case Cautious:
+		return 1.5
+	case Collectivist:
+		return 1.2
+	case Selfish:
+		return 0.4
+	default:
+		return 1.0
+	}
+}
+
+// ChildSurvival : devenir des enfants selon le profil de leurs parents
+type ChildSurvival struct {
+	Births int
+	Adults int
+	Deaths int
+}
+
+// Rate : part des enfants devenus adultes parmi ceux dont le sort est connu
+func (c ChildSurvival) Rate() float64 {
+	if c.Adults+c.Deaths == 0 {
+		return 0
+	}
+	return float64(c.Adults) / float64(c.Adults+c.Deaths)
+}
+
+type familyStats struct {
+	byProfile [ProfileCount]ChildSurvival
+	mutex     sync.Mutex
+}
+
+// record ajoute delta à chaque profil parental distinct de l'enfant
+func (f *familyStats) record(child *Human, update func(*ChildSurvival)) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	seen := [ProfileCount]bool{}
+	for _, p := range child.parentProfiles {
+		if !seen[p] {
+			seen[p] = true
+			update(&f.byProfile[p])
+		}
+	}
+}
+
+// GetChildSurvival renvoie naissances, passages à l'âge adulte et décès d'enfants par profil parental
+func (s *Simulation) GetChildSurvival() [ProfileCount]ChildSurvival {
+	f := &s.environment.families
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.byProfile
+}
+
+// adopt relie l'enfant à ses deux parents
+func adopt(child *Human, parents ...*Human) {
+	for _, p := range parents {
+		child.parents = append(child.parents, p.GetID())
+		child.parentProfiles = append(child.parentProfiles, p.profile)
+		p.children = append(p.children, child.GetID())
+	}
+}
+
+func (h *Human) GetChildrenIDs() []uint {
+	return h.children
+}
+
+func (h *Human) GetParentIDs() []uint {
+	return h.parents
+}
+
+// hungriestChild renvoie l'enfant visible (encore enfant) qui a le plus faim
+func (h *Human) hungriestChild() *Human {
+	var child *Human
+	for _, ag := range h.visibleAgents {
+		c, ok := ag.(*Human)
+		if !ok || !c.IsAlive() || c.GetLifeStage() != Child || !h.isParentOf(c) {
+			continue
+		}
+		if child == nil || c.hunger > child.hunger {
+			child = c
+		}
+	}
+	return child
+}
+
+func (h *Human) isParentOf(c *Human) bool {
+	for _, id := range c.parents {
+		if id == h.GetID() {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedChildAction : un parent va chercher à manger (ou puise dans son sac) et l'apporte à son enfant
+type FeedChildAction struct {
+	ChildID  uint
+	SourceID uint
+
+	carried uint
+	loaded  bool
+}
+
+func (f *FeedChildAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	child, ok := env.findAgent(f.ChildID).(*Human)
+	if !ok || !child.IsAlive() {
+		h.currentAction = nil
+		return
+	}
+
+	h.hunger += HungerCost
+	if h.hunger > MaxHunger {
+		h.hunger = MaxHunger
+	}
+
+	// L'Égoïste affamé abandonne son enfant
+	if h.profile == Selfish && h.hunger > HungryThreshold {
+		h.currentAction = nil
+		return
+	}
+
+	// Sans source repérée, le parent puise dans son sac
+	if !f.loaded && f.SourceID == 0 {
+		f.carried = h.takeFood(child.hunger)
+		f.loaded = true
+		if f.carried == 0 {
+			h.currentAction = nil
+		}
+		return
+	}
+
+	if !f.loaded {
+		source := env.findObject(f.SourceID)
+		if source == nil || !source.IsAlive() {
+			h.currentAction = nil
+			return
+		}
+		if !moveTowards(a, source.GetSprite().Position, env) {
+			return
+		}
+		switch food := source.(type) {
+		case *Vegetable:
+			value := food.GetHungerValue()
+			if food.Consume() {
+				f.carried = value
+			}
+		case *Carcass:
+			f.carried = food.Take(child.hunger)
+		}
+		f.loaded = true
+		if f.carried == 0 {
+			h.currentAction = nil
+		}
+		return
+	}
+
+	if moveTowards(a, child.GetSprite().Position, env) {
+		child.eat(f.carried)
+		f.carried = 0
+		h.currentAction = nil
+	}
+}
+
+func (f *FeedChildAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.profile == Selfish && h.hunger > HungryThreshold {
+		return 0.0
+	}
+
+	child := h.hungriestChild()
+	if child == nil {
+		return 0.0
+	}
+
+	// Source la plus proche de l'enfant : plante mûre ou viande d'une carcasse
+	var source Object
+	minDist := math.Inf(1)
+	cPos := child.GetSprite().Position
+	for _, obj := range h.visibleObjects {
+		switch food := obj.(type) {
+		case *Vegetable:
+			if !food.IsAlive() || food.GetStage() != Ripe {
+				continue
+			}
+		case *Carcass:
+			if !food.IsAlive() || food.GetMeat() == 0 {
+				continue
+			}
+		default:
+			continue
+		}
+		d := h.GetSprite().Position.DistanceTo(obj.GetSprite().Position) + obj.GetSprite().Position.DistanceTo(cPos)
+		if d < minDist {
+			minDist = d
+			source = obj
+		}
+	}
+	f.ChildID = child.GetID()
+	f.SourceID = 0
+	if source != nil {
+		f.SourceID = source.GetID()
+	} else if h.load() > 0 {
+		minDist = h.GetSprite().Position.DistanceTo(cPos)
+	} else {
+		return 0.0
+	}
+
+	utility := float64(child.hunger)*familyWeight(h.profile)*FeedUtilityBase - float64(h.hunger)*0.5 - minDist*0.1
+	return math.Max(0.0, utility)
+}
+
+// FollowParentAction : l'enfant reste près d'un de ses parents
+type FollowParentAction struct {
+	ParentID uint
+}
+
+func (f *FollowParentAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+	parent, ok := env.findAgent(f.ParentID).(*Human)
+	if !ok || !parent.IsAlive() {
+		h.currentAction = nil
+		return
+	}
+	if h.GetSprite().Position.DistanceTo(parent.GetSprite().Position) <= FollowDistance/2 {
+		h.currentAction = nil
+		return
+	}
+	moveTowards(a, parent.GetSprite().Position, env)
+}
+
+func (f *FollowParentAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.GetLifeStage() != Child || h.closestParent == nil {
+		return 0.0
+	}
+
+	dist := h.GetSprite().Position.DistanceTo(h.closestParent.GetSprite().Position)
+	if dist <= FollowDistance {
+		return 0.0
+	}
+	f.ParentID = h.closestParent.GetID()
+
+	// Plus l'enfant est loin, plus il veut rejoindre ses parents ; la faim le pousse à chercher seul
+	return math.Max(0.0, dist-float64(h.hunger)*0.3)
+}
+
+// perceptFamily repère le parent vivant le plus proche d'un enfant, parmi ceux qu'il voit
+func (h *Human) perceptFamily(env *Environment) {
+	h.closestParent = nil
+	if h.GetLifeStage() != Child {
+		return
+	}
+	minDist := h.vision
+	for _, id := range h.parents {
+		if p, ok := env.findAgent(id).(*Human); ok && p.IsAlive() {
+			d := h.GetSprite().Position.DistanceTo(p.GetSprite().Position)
+			if d <= minDist {
+				minDist = d
+				h.closestParent = p
+			}
+		}
+	}
+}
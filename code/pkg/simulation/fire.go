@@ -0,0 +1,275 @@
+package simulation
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	FireSize        = 14
+	FireRadius      = 80.0  // chaleur et lumière du feu
+	FireScareRadius = 120.0 // les loups n'approchent pas plus près
+	FuelPerWood     = 600   // ticks de combustion apportés par un bois
+	MaxFuel         = 1800
+	LowFuel         = 300 // en dessous, on pense à remettre du bois
+	FireEnergyCost  = 30
+	FireBase        = 120.0 // utilité d'allumer un feu quand on a froid
+	FeedFireBase    = 120.0
+	CookBase        = 100.0
+	CookFactor      = 1.5 // la viande cuite nourrit mieux
+)
+
+// fireKit : de quoi allumer un feu et le relancer une fois
+var fireKit = [MaterialKinds]int{Wood: 2, Flint: 1}
+
+// fireWeight : intérêt pour le feu selon le profil (le Prudent y voit une protection, le Collectiviste un bien commun)
+func fireWeight(p Profile) float64 {
+	switch p {
+	case Cautious:
+		return 1.5
+	case Collectivist:
+		return 1.2
+	case Selfish:
+		return 0.6
+	default:
+		return 1.0
+	}
+}
+
+// Fire : feu de camp, qui s'éteint s'il n'est pas alimenté en bois
+type Fire struct {
+	ObjectParams
+	fuel  int
+	mutex sync.Mutex
+}
+
+func CreateFire(id uint, x, y float64) *Fire {
+	return &Fire{
+		ObjectParams: ObjectParams{
+			id:     id,
+			name:   "Fire",
+			alive:  true,
+			sprite: CreateSprite(x, y, FireSize, FireSize),
+		},
+		fuel: FuelPerWood,
+	}
+}
+
+func (f *Fire) GetFuel() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.fuel
+}
+
+func (f *Fire) addWood() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.fuel = int(math.Min(MaxFuel, float64(f.fuel+FuelPerWood)))
+}
+
+// Burn est appelé à chaque tick par la simulation
+func (f *Fire) Burn() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.fuel--
+	if f.fuel <= 0 {
+		f.alive = false
+	}
+}
+
+func (f *Fire) extinguish() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.fuel = 0
+	f.alive = false
+}
+
+// GetFires renvoie les feux allumés (pour l'affichage)
+func (s *Simulation) GetFires() []*Fire {
+	fires := []*Fire{}
+	for _, o := range s.environment.objects {
+		if f, ok := o.(*Fire); ok && f.IsAlive() {
+			fires = append(fires, f)
+		}
+	}
+	return fires
+}
+
+// nearFire : un feu brûle à moins de radius
+func nearFire(pos Position, fires []Position, radius float64) bool {
+	for _, f := range fires {
+		if pos.DistanceTo(f) <= radius {
+			return true
+		}
+	}
+	return false
+}
+
+// fireNear : un feu brûle à moins de FireRadius (un animal tué là est cuit sur place)
+func (e *Environment) fireNear(pos Position) bool {
+	for _, o := range e.objects {
+		if f, ok := o.(*Fire); ok && f.IsAlive() && pos.DistanceTo(f.GetSprite().Position) <= FireRadius {
+			return true
+		}
+	}
+	return false
+}
+
+// perceptFire repère le feu visible le plus proche et si l'on profite de sa chaleur
+func (h *Human) perceptFire(env *Environment) {
+	h.closestFire = nil
+	minDist := math.Inf(1)
+	for _, obj := range h.visibleObjects {
+		if f, ok := obj.(*Fire); ok && f.IsAlive() {
+			d := h.GetSprite().Position.DistanceTo(f.GetSprite().Position)
+			if d < minDist {
+				minDist = d
+				h.closestFire = f
+			}
+		}
+	}
+	h.warm = minDist <= FireRadius
+}
+
+// chilly : la nuit d'hiver et la vague de froid font perdre de l'énergie à qui n'a ni abri ni feu
+func (h *Human) chilly(env *Environment) bool {
+	cold := env.hasDisaster(ColdSnap) || (h.night && env.calendar.Season(env.tick) == Winter)
+	return cold && !h.sheltered && !h.warm
+}
+
+// LightFireAction : allumer un feu avec un bois et un silex
+type LightFireAction struct{}
+
+func (l *LightFireAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	h.bag.mutex.Lock()
+	if h.bag.materials[Wood] < 1 || h.bag.materials[Flint] < 1 {
+		h.bag.mutex.Unlock()
+		h.currentAction = nil
+		return
+	}
+	h.bag.materials[Wood]--
+	h.bag.materials[Flint]--
+	h.bag.mutex.Unlock()
+
+	if h.energy >= FireEnergyCost {
+		h.energy -= FireEnergyCost
+	} else {
+		h.energy = 0
+	}
+	pos := h.GetSprite().Position
+	env.AddObject(CreateFire(env.newID(), pos.X, pos.Y))
+	h.currentAction = nil
+}
+
+func (l *LightFireAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.GetLifeStage() == Child || h.energy < FireEnergyCost || h.closestFire != nil {
+		return 0.0
+	}
+	mats := h.GetMaterials()
+	if mats[Wood] < 1 || mats[Flint] < 1 {
+		return 0.0
+	}
+
+	// Se réchauffer, se protéger des loups la nuit, cuire sa viande
+	need := 0.0
+	if h.cold {
+		need += 1
+	}
+	if h.night {
+		need += 0.5
+	}
+	if h.holding(MeatItem) > 0 {
+		need += 0.5
+	}
+	return FireBase * need * fireWeight(h.profile)
+}
+
+// FeedFireAction : remettre du bois dans le feu le plus proche
+type FeedFireAction struct {
+	FireID uint
+}
+
+func (f *FeedFireAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+	fire, ok := env.findObject(f.FireID).(*Fire)
+	if !ok || !fire.IsAlive() {
+		h.currentAction = nil
+		return
+	}
+	if !moveTowards(a, fire.GetSprite().Position, env) {
+		return
+	}
+
+	h.bag.mutex.Lock()
+	hasWood := h.bag.materials[Wood] > 0
+	if hasWood {
+		h.bag.materials[Wood]--
+	}
+	h.bag.mutex.Unlock()
+	if hasWood {
+		fire.addWood()
+	}
+	h.currentAction = nil
+}
+
+func (f *FeedFireAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.closestFire == nil || h.GetMaterials()[Wood] < 1 {
+		return 0.0
+	}
+	fuel := h.closestFire.GetFuel()
+	if fuel >= LowFuel {
+		return 0.0
+	}
+	f.FireID = h.closestFire.GetID()
+
+	dist := h.GetSprite().Position.DistanceTo(h.closestFire.GetSprite().Position)
+	utility := FeedFireBase*float64(LowFuel-fuel)/LowFuel*fireWeight(h.profile) - dist*0.1
+	return math.Max(0.0, utility)
+}
+
+// cookable : viande crue que l'on peut cuire sans que le surplus ne déborde du sac
+// (la viande cuite pèse CookFactor fois plus, ce qu'on mange tout de suite ne compte pas)
+func (h *Human) cookable() uint {
+	room := uint(float64(h.freeSpace()+h.hunger) / (CookFactor - 1))
+	if raw := h.holding(MeatItem); raw < room {
+		return raw
+	}
+	return room
+}
+
+// CookAction : cuire au feu la viande crue du sac
+type CookAction struct {
+	FireID uint
+}
+
+func (c *CookAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+	fire, ok := env.findObject(c.FireID).(*Fire)
+	if !ok || !fire.IsAlive() {
+		h.currentAction = nil
+		return
+	}
+	if !moveTowards(a, fire.GetSprite().Position, env) {
+		return
+	}
+
+	raw := h.takeItem(MeatItem, h.cookable())
+	h.collect(CookedMeatItem, uint(math.Floor(float64(raw)*CookFactor)))
+	h.currentAction = nil
+}
+
+func (c *CookAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.closestFire == nil || h.cookable() == 0 {
+		return 0.0
+	}
+	c.FireID = h.closestFire.GetID()
+
+	dist := h.GetSprite().Position.DistanceTo(h.closestFire.GetSprite().Position)
+	utility := CookBase + float64(h.hunger)*0.5 - dist*0.1
+	return math.Max(0.0, utility)
+}
@@ -0,0 +1,396 @@
+package simulation
+
+import "sort"
+
+const (
+	InteractionRange    = 40.0
+	InteractionCooldown = 300
+	PayoffHunger        = 10.0
+	PayoffEnergy        = 5.0
+	TrustDefect         = 0.05
+	TrustCooperate      = 0.02
+)
+
+type Move int
+
+const (
+	Cooperate Move = iota
+	Defect
+)
+
+type GameType int
+
+const (
+	GameNone GameType = iota
+	GamePrisonersDilemma
+	GamePublicGoods
+)
+
+// Game : un jeu à n joueurs, renvoie le gain de chacun
+type Game interface {
+	GetName() string
+	MinPlayers() int
+	MaxPlayers() int
+	NeedsCarcass() bool
+	Payoffs(moves []Move) []float64
+}
+
+// PrisonersDilemma se joue à deux autour d'une carcasse (T > R > P > S)
+type PrisonersDilemma struct {
+	Temptation float64
+	Reward     float64
+	Punishment float64
+	Sucker     float64
+}
+
+func CreatePrisonersDilemma() *PrisonersDilemma {
+	return &PrisonersDilemma{Temptation: 5, Reward: 3, Punishment: 1, Sucker: 0}
+}
+
+func (g *PrisonersDilemma) GetName() string    { return "Dilemme du prisonnier" }
+func (g *PrisonersDilemma) MinPlayers() int    { return 2 }
+func (g *PrisonersDilemma) MaxPlayers() int    { return 2 }
+func (g *PrisonersDilemma) NeedsCarcass() bool { return true }
+
+func (g *PrisonersDilemma) Payoffs(moves []Move) []float64 {
+	a, b := moves[0], moves[1]
+	switch {
+	case a == Cooperate && b == Cooperate:
+		return []float64{g.Reward, g.Reward}
+	case a == Cooperate && b == Defect:
+		return []float64{g.Sucker, g.Temptation}
+	case a == Defect && b == Cooperate:
+		return []float64{g.Temptation, g.Sucker}
+	default:
+		return []float64{g.Punishment, g.Punishment}
+	}
+}
+
+// PublicGoodsGame : chacun peut verser sa dotation dans un pot commun multiplié
+type PublicGoodsGame struct {
+	Endowment  float64
+	Multiplier float64
+}
+
+func CreatePublicGoodsGame() *PublicGoodsGame {
+	return &PublicGoodsGame{Endowment: 2, Multiplier: 1.6}
+}
+
+func (g *PublicGoodsGame) GetName() string    { return "Biens publics" }
+func (g *PublicGoodsGame) MinPlayers() int    { return 2 }
+func (g *PublicGoodsGame) MaxPlayers() int    { return 8 }
+func (g *PublicGoodsGame) NeedsCarcass() bool { return false }
+
+func (g *PublicGoodsGame) Payoffs(moves []Move) []float64 {
+	pot := 0.0
+	for _, m := range moves {
+		if m == Cooperate {
+			pot += g.Endowment
+		}
+	}
+	share := pot * g.Multiplier / float64(len(moves))
+
+	payoffs := make([]float64, len(moves))
+	for i, m := range moves {
+		payoffs[i] = share
+		if m == Defect {
+			payoffs[i] += g.Endowment
+		}
+	}
+	return payoffs
+}
+
+// Strategy choisit un coup selon ce que les adversaires ont joué la dernière fois
+type Strategy interface {
+	GetName() string
+	Choose(lastMoves []Move) Move
+}
+
+type AlwaysCooperate struct{}
+
+func (s AlwaysCooperate) GetName() string          { return "Toujours cooperer" }
+func (s AlwaysCooperate) Choose(last []Move) Move { return Cooperate }
+
+type AlwaysDefect struct{}
+
+func (s AlwaysDefect) GetName() string          { return "Toujours trahir" }
+func (s AlwaysDefect) Choose(last []Move) Move { return Defect }
+
+// TitForTat imite la majorité des adversaires (coopère au premier coup)
+type TitForTat struct{}
+
+func (s TitForTat) GetName() string { return "Donnant-donnant" }
+
+func (s TitForTat) Choose(last []Move) Move {
+	defects := 0
+	for _, m := range last {
+		if m == Defect {
+			defects++
+		}
+	}
+	if defects*2 > len(last) {
+		return Defect
+	}
+	return Cooperate
+}
+
+// GrimTrigger coopère jusqu'à la première trahison
+type GrimTrigger struct{}
+
+func (s GrimTrigger) GetName() string { return "Rancunier" }
+
+func (s GrimTrigger) Choose(last []Move) Move {
+	for _, m := range last {
+		if m == Defect {
+			return Defect
+		}
+	}
+	return Cooperate
+}
+
+func defaultStrategy(p Profile) Strategy {
+	switch p {
+	case Selfish:
+		return AlwaysDefect{}
+	case Collectivist:
+		return AlwaysCooperate{}
+	case Cautious:
+		return GrimTrigger{}
+	default:
+		return TitForTat{}
+	}
+}
+
+// PairResult : résultats d'un profil (ligne) face à un autre (colonne)
+type PairResult struct {
+	Games        int
+	Cooperations int
+	Payoff       float64
+}
+
+func (r PairResult) MeanPayoff() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return r.Payoff / float64(r.Games)
+}
+
+func (r PairResult) CooperationRate() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Cooperations) / float64(r.Games)
+}
+
+// InteractionModule fait jouer les humains proches les uns des autres
+type InteractionModule struct {
+	Game       Game
+	strategies [ProfileCount]Strategy
+
+	// memory[i][j] : dernier coup joué par j contre i
+	memory     map[uint]map[uint]Move
+	lastPlayed map[uint]int
+	results    [ProfileCount][ProfileCount]PairResult
+}
+
+func CreateInteractionModule(game Game) *InteractionModule {
+	m := &InteractionModule{
+		Game:       game,
+		memory:     make(map[uint]map[uint]Move),
+		lastPlayed: make(map[uint]int),
+	}
+	for p := 0; p < ProfileCount; p++ {
+		m.strategies[p] = defaultStrategy(Profile(p))
+	}
+	return m
+}
+
+func (m *InteractionModule) SetStrategy(p Profile, s Strategy) {
+	m.strategies[p] = s
+}
+
+func (m *InteractionModule) GetStrategy(p Profile) Strategy {
+	return m.strategies[p]
+}
+
+func (m *InteractionModule) GetResults() [ProfileCount][ProfileCount]PairResult {
+	return m.results
+}
+
+// Play est appelé entre deux ticks (aucune goroutine d'agent ne tourne)
+func (m *InteractionModule) Play(env *Environment) {
+	humans := []*Human{}
+	for _, a := range env.agents {
+		if h, ok := a.(*Human); ok && h.IsAlive() && m.isReady(h, env.tick) {
+			humans = append(humans, h)
+		}
+	}
+	carcasses := []*Carcass{}
+	for _, o := range env.objects {
+		if c, ok := o.(*Carcass); ok && c.IsAlive() {
+			carcasses = append(carcasses, c)
+		}
+	}
+
+	busy := make(map[uint]bool)
+	for _, h := range humans {
+		if busy[h.GetID()] {
+			continue
+		}
+		available, take := m.source(h, carcasses, env)
+		if available == 0 {
+			continue
+		}
+
+		group := m.formGroup(h, humans, busy)
+		if len(group) < m.Game.MinPlayers() {
+			continue
+		}
+		for _, p := range group {
+			busy[p.GetID()] = true
+			m.lastPlayed[p.GetID()] = env.tick
+		}
+		m.playRound(group, available, take)
+	}
+}
+
+// source : nourriture d'où sont tirés les gains, la carcasse partagée ou la réserve du campement
+func (m *InteractionModule) source(h *Human, carcasses []*Carcass, env *Environment) (uint, func(uint) [ItemKinds]uint) {
+	if m.Game.NeedsCarcass() {
+		c := nearCarcass(h, carcasses)
+		if c == nil {
+			return 0, nil
+		}
+		return c.GetMeat(), func(amount uint) [ItemKinds]uint {
+			var taken [ItemKinds]uint
+			taken[c.GetKind()] = c.Take(amount)
+			return taken
+		}
+	}
+
+	// Les biens publics se jouent au campement, sur la réserve commune
+	camp := env.camp(h.tribe)
+	if camp == nil || h.GetSprite().Position.DistanceTo(camp.GetSprite().Position) > CampRadius {
+		return 0, nil
+	}
+	return camp.GetStock(), camp.withdraw
+}
+
+func (m *InteractionModule) isReady(h *Human, tick int) bool {
+	last, ok := m.lastPlayed[h.GetID()]
+	return !ok || tick-last >= InteractionCooldown
+}
+
+// nearCarcass renvoie une carcasse non vide à portée (nil sinon)
+func nearCarcass(h *Human, carcasses []*Carcass) *Carcass {
+	for _, c := range carcasses {
+		if c.GetMeat() > 0 && h.GetSprite().Position.DistanceTo(c.GetSprite().Position) <= InteractionRange {
+			return c
+		}
+	}
+	return nil
+}
+
+// formGroup réunit les joueurs disponibles les plus proches
+func (m *InteractionModule) formGroup(h *Human, humans []*Human, busy map[uint]bool) []*Human {
+	pos := h.GetSprite().Position
+	others := []*Human{}
+	for _, o := range humans {
+		if o == h || busy[o.GetID()] {
+			continue
+		}
+		if pos.DistanceTo(o.GetSprite().Position) <= InteractionRange {
+			others = append(others, o)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool {
+		return pos.DistanceTo(others[i].GetSprite().Position) < pos.DistanceTo(others[j].GetSprite().Position)
+	})
+
+	group := []*Human{h}
+	for _, o := range others {
+		if len(group) >= m.Game.MaxPlayers() {
+			break
+		}
+		group = append(group, o)
+	}
+	return group
+}
+
+// playRound fait jouer le groupe ; les gains sont prélevés sur la nourriture disponible,
+// réduits en proportion si elle ne suffit pas
+func (m *InteractionModule) playRound(group []*Human, available uint, take func(uint) [ItemKinds]uint) {
+	moves := make([]Move, len(group))
+	for i, p := range group {
+		last := []Move{}
+		for _, o := range group {
+			if o != p {
+				if mv, ok := m.memory[p.GetID()][o.GetID()]; ok {
+					last = append(last, mv)
+				}
+			}
+		}
+		moves[i] = m.strategies[p.profile].Choose(last)
+	}
+
+	payoffs := m.Game.Payoffs(moves)
+	total := 0.0
+	for _, pay := range payoffs {
+		total += pay * PayoffHunger
+	}
+	if total > float64(available) {
+		for i := range payoffs {
+			payoffs[i] *= float64(available) / total
+		}
+	}
+
+	for i, p := range group {
+		p.applyPayoff(take(uint(payoffs[i] * PayoffHunger)))
+
+		if m.memory[p.GetID()] == nil {
+			m.memory[p.GetID()] = make(map[uint]Move)
+		}
+		for j, o := range group {
+			if i == j {
+				continue
+			}
+			m.memory[p.GetID()][o.GetID()] = moves[j]
+			if moves[j] == Defect {
+				p.adjustTrust(o.GetID(), -TrustDefect)
+			} else {
+				p.adjustTrust(o.GetID(), TrustCooperate)
+			}
+
+			r := &m.results[p.profile][o.profile]
+			r.Games++
+			r.Payoff += payoffs[i]
+			if moves[i] == Cooperate {
+				r.Cooperations++
+			}
+		}
+	}
+}
+
+// applyPayoff : la nourriture gagnée est mangée ou rangée dans le sac, et redonne de l'énergie
+func (h *Human) applyPayoff(food [ItemKinds]uint) {
+	got := uint(0)
+	for k, n := range food {
+		h.collect(ItemKind(k), n)
+		got += n
+	}
+	h.energy += uint(float64(got) / PayoffHunger * PayoffEnergy)
+	if h.energy > MaxEnergy {
+		h.energy = MaxEnergy
+	}
+}
+
+func CreateGame(typ GameType) Game {
+	switch typ {
+	case GamePrisonersDilemma:
+		return CreatePrisonersDilemma()
+	case GamePublicGoods:
+		return CreatePublicGoodsGame()
+	default:
+		return nil
+	}
+}
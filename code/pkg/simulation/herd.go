@@ -0,0 +1,172 @@
+package simulation
+
+import "math"
+
+const (
+	HerdRadius       = 150.0
+	SeparationRadius = 25.0
+	CohesionWeight   = 0.01
+	AlignmentWeight  = 0.5
+	SeparationWeight = 1.5
+	LeaderWeight     = 0.02
+	StragglerBias    = 30.0
+	AlarmRelay       = 0.7 // affaiblissement de l'alerte à chaque relais
+	AlarmMinLevel    = 0.1 // en dessous, l'alerte n'est plus relayée
+)
+
+// isHerding : seuls les bovins (vaches et taureaux) vivent en troupeau
+func (t AnimalType) isHerding() bool {
+	return t == Cow || t == Bull
+}
+
+// herdSignal : ce que les autres membres du troupeau voient d'un animal (protégé par a.mutex)
+type herdSignal struct {
+	velocity Position
+	alarm    Position
+	size     int
+}
+
+func (a *Animal) getSignal() herdSignal {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.signal
+}
+
+func (a *Animal) setSignal(s herdSignal) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.signal = s
+}
+
+// updateSignal publie la vitesse du tick et l'alerte : menaces vues par l'animal lui-même,
+// ou à défaut alerte reçue du troupeau, relayée en s'affaiblissant
+func (a *Animal) updateSignal(previous Position) {
+	if !a.typ.isHerding() {
+		return
+	}
+	pos := a.GetSprite().Position
+	sig := herdSignal{
+		velocity: Position{X: pos.X - previous.X, Y: pos.Y - previous.Y},
+		size:     len(a.herd) + 1,
+	}
+
+	for _, threat := range a.detectedThreats {
+		tPos := threat.GetSprite().Position
+		sig.alarm.X += pos.X - tPos.X
+		sig.alarm.Y += pos.Y - tPos.Y
+	}
+	sig.alarm = normalize(sig.alarm)
+	if sig.alarm == (Position{}) && a.alarmLevel*AlarmRelay >= AlarmMinLevel {
+		relay := normalize(a.herdAlarm)
+		sig.alarm = Position{X: relay.X * a.alarmLevel * AlarmRelay, Y: relay.Y * a.alarmLevel * AlarmRelay}
+	}
+	a.setSignal(sig)
+}
+
+func (a *Animal) IsHerdLeader() bool { return a.typ.isHerding() && a.leader == nil }
+
+// GetHerdSize peut être lu par les chasseurs (taille publiée au tick précédent)
+func (a *Animal) GetHerdSize() int {
+	if size := a.getSignal().size; size > 0 {
+		return size
+	}
+	return 1
+}
+
+// perceptHerd : le chef est le bovin d'identifiant le plus petit parmi les voisins
+func (a *Animal) perceptHerd(env *Environment) {
+	a.herd = a.herd[:0]
+	a.leader = nil
+	a.herdAlarm = Position{}
+	a.alarmLevel = 0
+	if !a.typ.isHerding() {
+		return
+	}
+
+	pos := a.GetSprite().Position
+	for _, agent := range env.agents {
+		other, ok := agent.(*Animal)
+		if !ok || other == a || !other.IsAlive() || !other.typ.isHerding() {
+			continue
+		}
+		if pos.DistanceTo(other.GetSprite().Position) > HerdRadius {
+			continue
+		}
+		a.herd = append(a.herd, other)
+		if other.GetID() < a.GetID() && (a.leader == nil || other.GetID() < a.leader.GetID()) {
+			a.leader = other
+		}
+
+		// L'alerte d'un voisin est relayée de proche en proche à tout le troupeau
+		alarm := other.getSignal().alarm
+		a.herdAlarm.X += alarm.X
+		a.herdAlarm.Y += alarm.Y
+		a.alarmLevel = math.Max(a.alarmLevel, math.Hypot(alarm.X, alarm.Y))
+	}
+}
+
+func (a *Animal) isHerdAlarmed() bool {
+	return a.herdAlarm.X != 0 || a.herdAlarm.Y != 0
+}
+
+// fleeVector : fuite loin des menaces vues, plus la direction de fuite du troupeau
+func (a *Animal) fleeVector() Position {
+	pos := a.GetSprite().Position
+	var v Position
+	for _, threat := range a.detectedThreats {
+		tPos := threat.GetSprite().Position
+		v.X += pos.X - tPos.X
+		v.Y += pos.Y - tPos.Y
+	}
+	v = normalize(v)
+	herd := normalize(a.herdAlarm)
+	return normalize(Position{X: v.X + herd.X, Y: v.Y + herd.Y})
+}
+
+// boidsVector : cohésion, alignement, séparation, et suivi du chef
+func (a *Animal) boidsVector() Position {
+	pos := a.GetSprite().Position
+	var center, align, separate Position
+
+	for _, mate := range a.herd {
+		mPos := mate.GetSprite().Position
+		center.X += mPos.X
+		center.Y += mPos.Y
+
+		vel := mate.getSignal().velocity
+		align.X += vel.X
+		align.Y += vel.Y
+
+		d := pos.DistanceTo(mPos)
+		if d > 0 && d < SeparationRadius {
+			separate.X += (pos.X - mPos.X) / (d * d)
+			separate.Y += (pos.Y - mPos.Y) / (d * d)
+		}
+	}
+
+	n := float64(len(a.herd))
+	var v Position
+	if n > 0 {
+		v.X += (center.X/n - pos.X) * CohesionWeight
+		v.Y += (center.Y/n - pos.Y) * CohesionWeight
+		v.X += align.X / n * AlignmentWeight
+		v.Y += align.Y / n * AlignmentWeight
+	}
+	v.X += separate.X * SeparationWeight * SeparationRadius
+	v.Y += separate.Y * SeparationWeight * SeparationRadius
+
+	if a.leader != nil {
+		lPos := a.leader.GetSprite().Position
+		v.X += (lPos.X - pos.X) * LeaderWeight
+		v.Y += (lPos.Y - pos.Y) * LeaderWeight
+	}
+	return v
+}
+
+func normalize(v Position) Position {
+	length := math.Sqrt(v.X*v.X + v.Y*v.Y)
+	if length == 0 {
+		return Position{}
+	}
+	return Position{X: v.X / length, Y: v.Y / length}
+}
@@ -20,6 +20,74 @@ type Human struct {
 	visibleObjects []Object
 	tickCounter    int 
 	actionDuration int
+
+	// Communication
+	inbox          []Message
+	huntCalls      []*Animal
+	knownFood      []Object
+	informCooldown int
+
+	// Jour / nuit : portée de vue du tick et dette de sommeil
+	vision    float64
+	night     bool
+	sleepDebt int
+
+	// Catastrophes : froid ressenti
+	cold bool
+
+	// Maladie (modèle SIR)
+	disease disease
+
+	// Âge en ticks (enfant, adulte, ancien)
+	age int
+
+	// Famille : identifiants des parents et des enfants
+	parents        []uint
+	parentProfiles []Profile
+	children       []uint
+	closestParent  *Human
+
+	// Reproduction : sexe, grossesse (ticks restants) et couple
+	sex           Sex
+	pregnancy     int
+	birthCooldown int
+	father        *Human
+	partner       *Human
+	partnerID     uint
+	pairBonding   bool
+
+	// Tribu d'appartenance (0 s'il n'y en a qu'une)
+	tribe int
+
+	// Conflits : agresseur direct, agresseur d'un membre de la tribu, territoires perçus
+	attacker     *Human
+	allyAttacker *Human
+	territory    *territory
+
+	// Nourriture transportée, délai avant de proposer un nouveau troc
+	bag           inventory
+	tradeCooldown int
+
+	// Campement de la tribu et protection de son abri
+	camp      *Camp
+	sheltered bool
+
+	// Feu visible le plus proche, et sa chaleur
+	closestFire *Fire
+	warm        bool
+
+	// Loup le plus proche à portée de danger (nil si aucun)
+	closestPredator *Predator
+
+	// Nourriture reçue depuis la naissance (équité)
+	foodReceived uint
+
+	// Confiance envers les autres humains
+	trust trustBook
+
+	// Contract Net (manager)
+	contract         *HuntContract
+	contractCooldown int
 }
 
 // CreateHuman initialise un humain
@@ -34,6 +102,9 @@ func CreateHuman(name string, health int, sprite Sprite, hunger, energy uint, pr
 		energy:         energy,
 		visibleAgents:  []Agent{},
 		visibleObjects: []Object{},
+		huntCalls:      []*Animal{},
+		knownFood:      []Object{},
+		trust:          trustBook{scores: make(map[uint]float64)},
 		tickCounter:    0,
 		actionDuration: 0,
 	}
@@ -79,27 +150,152 @@ func (h *Human) GetCurrentAction() Action {
 	return h.currentAction 
 }
 
+func (h *Human) GetSleepDebt() int {
+	return h.sleepDebt
+}
+
+func (h *Human) GetFoodReceived() uint {
+	return h.foodReceived
+}
+
+// eat réduit la faim et comptabilise la nourriture reçue
+func (h *Human) eat(val uint) {
+	h.foodReceived += val
+	if h.hunger < val {
+		h.hunger = 0
+	} else {
+		h.hunger -= val
+	}
+}
+
 func (h *Human) Percept(env *Environment) {
 	h.visibleAgents = []Agent{}
 	h.visibleObjects = []Object{}
+	h.closestPredator = nil
+	minPredatorDist := DangerRadius
+	h.night = env.isNight()
+	h.collectReceived()
+	h.perceptCamp(env)
+	h.pairBonding = env.mating.PairBonding
+	h.vision = VisionRadius * env.visionFactor()
+	if h.GetLifeStage() == Elder {
+		h.vision *= ElderVisionFactor
+	}
 
 	for _, a := range env.agents {
 		if a.GetID() == h.GetID() || !a.IsAlive() { continue }
-		if h.GetSprite().Position.DistanceTo(a.GetSprite().Position) <= VisionRadius {
+		d := h.GetSprite().Position.DistanceTo(a.GetSprite().Position)
+		if d <= h.vision {
 			h.visibleAgents = append(h.visibleAgents, a)
 		}
+		if p, ok := a.(*Predator); ok && d < minPredatorDist {
+			minPredatorDist = d
+			h.closestPredator = p
+		}
 	}
 
 	for _, o := range env.objects {
 		if !o.IsAlive() { continue }
-		if h.GetSprite().Position.DistanceTo(o.GetSprite().Position) <= VisionRadius {
+		if h.GetSprite().Position.DistanceTo(o.GetSprite().Position) <= h.vision {
 			h.visibleObjects = append(h.visibleObjects, o)
 		}
 	}
+
+	h.perceptFire(env)
+	h.cold = h.chilly(env)
+
+	h.meetOutsiders()
+	h.perceptConflict(env)
+	h.perceptFamily(env)
+	h.readMessages(env)
+	h.manageContract(env)
+	h.answerContracts()
+	h.answerTrades(env)
+}
+
+// readMessages interprète les messages reçus au tick précédent
+func (h *Human) readMessages(env *Environment) {
+	h.inbox = h.tribeMessages(env, h.ReadMessages())
+	h.huntCalls = []*Animal{}
+	h.knownFood = []Object{}
+
+	for _, msg := range h.inbox {
+		switch msg.Topic {
+		case TopicHelp:
+			if msg.Performative != Request {
+				continue
+			}
+			if ani, ok := env.findAgent(msg.TargetID).(*Animal); ok && ani.IsAlive() {
+				h.huntCalls = append(h.huntCalls, ani)
+			}
+		case TopicFood:
+			if msg.Performative != Inform {
+				continue
+			}
+			if veg, ok := env.findObject(msg.TargetID).(*Vegetable); ok && veg.IsAlive() {
+				h.knownFood = append(h.knownFood, veg)
+			}
+		}
+	}
+}
+
+// communicate envoie les messages liés à la décision qui vient d'être prise
+func (h *Human) communicate() {
+	huntTarget := uint(0)
+	mateID := uint(0)
+
+	switch act := h.currentAction.(type) {
+	case *HuntAction:
+		huntTarget = act.TargetID
+	case *ReproduceAction:
+		mateID = act.MateID
+		h.Send(CreateMessage(h.GetID(), act.MateID, Propose, TopicMate, act.MateID, h.GetSprite().Position))
+	}
+
+	// Réponses aux appels et propositions reçus
+	for _, msg := range h.inbox {
+		if msg.Performative == Propose && msg.Topic == TopicMate {
+			if msg.From == mateID {
+				h.Send(CreateMessage(h.GetID(), msg.From, Accept, TopicMate, h.GetID(), h.GetSprite().Position))
+			} else {
+				h.Send(CreateMessage(h.GetID(), msg.From, Reject, TopicMate, h.GetID(), h.GetSprite().Position))
+			}
+			continue
+		}
+		if msg.Performative != Request || msg.Topic != TopicHelp {
+			continue
+		}
+		if msg.TargetID == huntTarget {
+			h.Send(CreateMessage(h.GetID(), msg.From, Accept, msg.Topic, msg.TargetID, h.GetSprite().Position))
+		} else {
+			h.Send(CreateMessage(h.GetID(), msg.From, Reject, msg.Topic, msg.TargetID, h.GetSprite().Position))
+		}
+	}
+
+	// Les profils coopératifs signalent la nourriture qu'ils ne prennent pas
+	if h.informCooldown > 0 || (h.profile != Collectivist && h.profile != Pragmatic) {
+		return
+	}
+	gatherTarget := uint(0)
+	if act, ok := h.currentAction.(*GatherAction); ok {
+		gatherTarget = act.TargetID
+	}
+	for _, obj := range h.visibleObjects {
+		if veg, ok := obj.(*Vegetable); ok && veg.IsAlive() && veg.GetID() != gatherTarget {
+			h.Send(CreateMessage(h.GetID(), BroadcastID, Inform, TopicFood, veg.GetID(), veg.GetSprite().Position))
+			h.informCooldown = InformCooldown
+			return
+		}
+	}
 }
 
 func (h *Human) Deliberate() {
-	if h.currentAction != nil && h.actionDuration < 90 {
+	// Un loup ou une attaque interrompt l'action en cours
+	_, fleeing := h.currentAction.(*FleeAction)
+	_, fighting := h.currentAction.(*FightAction)
+	threatened := (h.closestPredator != nil || h.attacker != nil || h.allyAttacker != nil) && !fleeing && !fighting
+
+	if h.currentAction != nil && (h.actionDuration < 90 || h.isContractBound()) && !threatened {
 		return
 	}
 
@@ -110,6 +306,22 @@ func (h *Human) Deliberate() {
 		&GatherAction{},
 		&HuntAction{},
 		&ReproduceAction{},
+		&ShareFoodAction{},
+		&FleeAction{},
+		&CareAction{},
+		&FeedChildAction{},
+		&FollowParentAction{},
+		&FightAction{},
+		&BuildAction{},
+		&DepositAction{},
+		&WithdrawAction{},
+		&EatAction{},
+		&TradeAction{},
+		&CollectMaterialAction{},
+		&CraftAction{},
+		&LightFireAction{},
+		&FeedFireAction{},
+		&CookAction{},
 	}
 
 	var bestAction Action
@@ -127,17 +339,28 @@ func (h *Human) Deliberate() {
 		h.currentAction = bestAction
 		h.actionDuration = 0
 	}
+
+	h.communicate()
 }
 
 func (h *Human) Act(env *Environment) {
 	h.tickCounter++
+	h.grow(env)
 
 	if h.tickCounter >= 30 {
 		h.tickCounter = 0
 		
-		if h.energy > 0 {
-			h.energy--
+		drain := uint(1)
+		if h.cold {
+			drain += ColdEnergyDrain
+		}
+		if h.IsInfected() {
+			drain += DiseaseEnergyDrain
+		}
+		if h.energy >= drain {
+			h.energy -= drain
 		} else {
+			h.energy = 0
 			h.IsAttacked(1)
 		}
 
@@ -152,10 +375,38 @@ func (h *Human) Act(env *Environment) {
 		return
 	}
 
+	h.progressDisease()
+	h.catchDisease()
+	h.progressPregnancy(env)
+	h.spoilFood()
+
+	// Rester éveillé fatigue, l'épuisement coûte de l'énergie
+	if _, resting := h.currentAction.(*RestAction); !resting {
+		if h.sleepDebt < MaxSleepDebt {
+			h.sleepDebt++
+		} else if h.tickCounter == 0 && h.energy > 0 {
+			h.energy--
+		}
+	}
+
+	if h.informCooldown > 0 {
+		h.informCooldown--
+	}
+	if h.contractCooldown > 0 {
+		h.contractCooldown--
+	}
+	if h.tradeCooldown > 0 {
+		h.tradeCooldown--
+	}
+
 	if h.currentAction != nil {
 		h.currentAction.Execute(h, env)
 		h.actionDuration++
 	} else {
 		h.actionDuration = 0
 	}
+	h.avoidSick(env)
+	h.joinPartner(env)
+
+	h.flushOutbox(env)
 }
\ No newline at end of file
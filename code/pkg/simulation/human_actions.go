@@ -29,10 +29,15 @@ func moveTowards(a Agent, target Position, env *Environment) bool {
 	dx := target.X - pos.X
 	dy := target.Y - pos.Y
 	
+	speed := MoveSpeed
+	if h, ok := a.(*Human); ok {
+		speed = h.speed()
+	}
+
 	length := math.Sqrt(dx*dx + dy*dy)
 	if length > 0 {
-		dx = (dx / length) * MoveSpeed
-		dy = (dy / length) * MoveSpeed
+		dx = (dx / length) * speed
+		dy = (dy / length) * speed
 	}
 
 	a.Move(dx, dy, env)
@@ -45,7 +50,8 @@ func (r *RestAction) Execute(a Agent, env *Environment) {
 	h := a.(*Human)
 	
 	// Modulo 2 : Récupère de l'énergie tous les 2 ticks (environ 30 fois par seconde)
-	if h.actionDuration % 2 == 0 {
+	// La nuit, à l'abri ou près du feu, le sommeil est réparateur : récupération à chaque tick
+	if h.night || h.sheltered || h.warm || h.actionDuration % 2 == 0 {
 		h.energy += EnergyRestRate
 		if h.energy > MaxEnergy {
 			h.energy = MaxEnergy
@@ -53,6 +59,15 @@ func (r *RestAction) Execute(a Agent, env *Environment) {
 		
 	}
 
+	recovery := 1
+	if h.night {
+		recovery = NightRestBonus
+	}
+	h.sleepDebt -= recovery
+	if h.sleepDebt < 0 {
+		h.sleepDebt = 0
+	}
+
 	if h.actionDuration % 4 == 0 {
 		h.hunger += HungerCost 
 		if h.hunger > MaxHunger {
@@ -60,8 +75,12 @@ func (r *RestAction) Execute(a Agent, env *Environment) {
 		}
 	}
 
-	// Modulo 5 : Soigne tous les 5 ticks (environ 6 fois par seconde)
-	if h.actionDuration % 5 == 0 {
+	// Modulo 5 : Soigne tous les 5 ticks (environ 6 fois par seconde), deux fois plus vite à l'abri
+	healInterval := 5
+	if h.sheltered {
+		healInterval = 2
+	}
+	if h.actionDuration % healInterval == 0 {
 		if h.health < MaxHealth {
 			h.health += 1 // +1 PV
 			if h.health > MaxHealth {
@@ -80,6 +99,11 @@ func (r *RestAction) evaluateUtility(a Agent, env *Environment) float64 {
 
 	utility := utilityEnergy + utilityHealth
 
+	// Besoin de dormir, surtout la nuit
+	if h.night {
+		utility += float64(h.sleepDebt) * SleepWeight
+	}
+
 	switch h.profile {
 	case Cautious:
 		utility *= 1.25
@@ -122,12 +146,11 @@ func (g *GatherAction) Execute(a Agent, env *Environment) {
 	if h.hunger > MaxHunger { h.hunger = MaxHunger }
 
 	if arrived {
-		target.Consume()
-		val := target.GetHungerValue()
-		if h.hunger < val {
-			h.hunger = 0
-		} else {
-			h.hunger -= val
+		if target.Consume() {
+			h.collect(PlantItem, target.GetHungerValue())
+			if h.hasTool(Basket) {
+				h.useTool(Basket, env)
+			}
 		}
 		h.currentAction = nil
 	}
@@ -135,10 +158,17 @@ func (g *GatherAction) Execute(a Agent, env *Environment) {
 
 func (g *GatherAction) evaluateUtility(a Agent, env *Environment) float64 {
 	h := a.(*Human)
+	// Un enfant dépend de ses parents ; seul celui qui n'en voit aucun se nourrit lui-même
+	if h.GetLifeStage() == Child && h.closestParent != nil {
+		return 0.0
+	}
 	var closest *Vegetable
 	minDist := 99999.0
 
-	for _, obj := range h.visibleObjects {
+	candidates := append([]Object{}, h.visibleObjects...)
+	candidates = append(candidates, h.knownFood...)
+
+	for _, obj := range candidates {
 		if veg, ok := obj.(*Vegetable); ok && veg.IsAlive() {
 			
 			alreadyTargeted := false
@@ -154,11 +184,13 @@ func (g *GatherAction) evaluateUtility(a Agent, env *Environment) float64 {
 				}
 			}
 
-			if alreadyTargeted {
+			if alreadyTargeted || !h.willHarvest(veg) {
 				continue
 			}
 
+			// Une plante mûre vaut le détour
 			d := h.GetSprite().Position.DistanceTo(veg.GetSprite().Position)
+			d += (1 - veg.GetRipeness()) * UnripeBias
 			if d < minDist {
 				minDist = d
 				closest = veg
@@ -173,7 +205,9 @@ func (g *GatherAction) evaluateUtility(a Agent, env *Environment) float64 {
 	g.TargetID = closest.GetID()
 	g.TargetPos = closest.GetSprite().Position
 
-	utility := float64(h.hunger) - (minDist * 0.1)
+	// On cueille aussi pour remplir son sac
+	free := 1 - float64(h.load())/float64(h.capacity())
+	utility := float64(h.hunger) + free*CarryUtility - (minDist * 0.1)
 
 	switch h.profile {
 	case Pragmatic:
@@ -183,12 +217,39 @@ func (g *GatherAction) evaluateUtility(a Agent, env *Environment) float64 {
 	case Cautious:
 		utility *= 1.5
 	}
+	if h.GetLifeStage() == Child {
+		utility *= OrphanGatherFactor
+	}
 
 	return utility
 }
 
+// willHarvest : récolter tout de suite ou attendre la maturité ?
+func (h *Human) willHarvest(veg *Vegetable) bool {
+	if veg.GetStage() == Ripe {
+		return true
+	}
+	switch h.profile {
+	case Selfish:
+		// L'égoïste prend tout ce qu'il trouve avant les autres
+		return true
+	case Collectivist:
+		// Le collectiviste laisse pousser, sauf urgence
+		return h.hunger > MaxHunger*7/10
+	default:
+		return veg.GetStage() == Growing && h.hunger > MaxHunger/2
+	}
+}
+
 type HuntAction struct {
-	TargetID uint
+	TargetID   uint
+	TargetPos  Position
+	ContractID uint
+
+	needed        int
+	helpRequested bool
+	started       bool
+	waiting       int
 }
 
 func (hu *HuntAction) Execute(a Agent, env *Environment) {
@@ -209,6 +270,29 @@ func (hu *HuntAction) Execute(a Agent, env *Environment) {
 		return
 	}
 
+	// Gros gibier : la chasse passe par un appel d'offres
+	if target.GetPeopleNeeded() > 1 && hu.ContractID == 0 {
+		c := h.openContract(target, env)
+		if c == nil {
+			h.currentAction = nil
+			return
+		}
+		hu.ContractID = c.ID
+	}
+
+	if hu.ContractID != 0 && !hu.started {
+		hu.waiting++
+		if h.contract == nil && hu.waiting > BidWindow*2+CommitTimeout {
+			h.currentAction = nil
+			return
+		}
+		// Rendez-vous à distance pour ne pas effrayer la cible
+		if h.GetSprite().Position.DistanceTo(target.GetSprite().Position) > RendezvousDistance {
+			moveTowards(a, target.GetSprite().Position, env)
+		}
+		return
+	}
+
 	arrived := moveTowards(a, target.GetSprite().Position, env)
 	
 	if h.energy >= EnergyMoveCost { h.energy -= EnergyMoveCost }
@@ -232,22 +316,36 @@ func (hu *HuntAction) Execute(a Agent, env *Environment) {
 		}
 
 		if hunters >= target.GetPeopleNeeded() {
-			target.IsAttacked(20)
-			h.IsAttacked(4)
+			target.hitBy(h.GetID(), h.huntDamage(20, env))
 		} else {
-			target.IsAttacked(10)
+			target.hitBy(h.GetID(), h.huntDamage(10, env))
+			if !hu.helpRequested {
+				h.Send(CreateMessage(h.GetID(), BroadcastID, Request, TopicHelp, target.GetID(), target.GetSprite().Position))
+				hu.helpRequested = true
+			}
 		}
 
-		if target.GetHealth() <= 0 {
+		if target.IsAlive() {
+			target.counterAttack(h, hunters, env)
+			if h.health < retreatHealth(h.profile) {
+				h.currentAction = nil
+				return
+			}
+		}
+
+		if target.GetHealth() <= 0 && target.butcher() {
 			target.Kill()
-			val := target.GetHungerValue()/uint(target.GetPeopleNeeded())
+			// Nouvel ID : la carcasse est un objet distinct de l'animal
+			carcass := CreateCarcass(env.newID(), target.GetSprite(), target.GetHungerValue(), participatingHunters)
+			if env.fireNear(target.GetSprite().Position) {
+				carcass.cook()
+			}
+			env.AddObject(carcass)
+			damage := target.damageReport()
+			observeHunt(participatingHunters, damage)
+			distributeCarcass(carcass, participatingHunters, damage)
 
 			for _, hunter := range participatingHunters {
-				if hunter.hunger < val {
-					hunter.hunger = 0
-				} else {
-					hunter.hunger -= val
-				}
 				hunter.currentAction = nil
 			}
 		}
@@ -260,6 +358,10 @@ func (hu *HuntAction) Execute(a Agent, env *Environment) {
 
 func (hu *HuntAction) evaluateUtility(a Agent, env *Environment) float64 {
 	h := a.(*Human)
+	// Les enfants ne chassent pas
+	if h.GetLifeStage() == Child {
+		return 0.0
+	}
 	var closest *Animal
 	minDist := 99999.0
 
@@ -279,13 +381,32 @@ func (hu *HuntAction) evaluateUtility(a Agent, env *Environment) float64 {
 		}
 	}
 
+	// Animaux visibles + animaux signalés par un appel à la chasse
+	candidates := []*Animal{}
+	called := make(map[uint]bool)
 	for _, ag := range h.visibleAgents {
-		if ani, ok := ag.(*Animal); ok && ani.IsAlive() {
+		if ani, ok := ag.(*Animal); ok {
+			candidates = append(candidates, ani)
+		}
+	}
+	for _, ani := range h.huntCalls {
+		if !called[ani.GetID()] {
+			called[ani.GetID()] = true
+			candidates = append(candidates, ani)
+		}
+	}
+
+	for _, ani := range candidates {
+		if ani.IsAlive() {
 			
-			if !isAlreadyHunting {
+			if !isAlreadyHunting && !called[ani.GetID()] {
 				if (1 + visibleAlliesCount) < ani.GetPeopleNeeded() {
 					continue
 				}
+				// Un appel d'offres vient d'échouer
+				if ani.GetPeopleNeeded() > 1 && h.contractCooldown > 0 {
+					continue
+				}
 			}
 
 			for _, neighbor := range h.visibleAgents {
@@ -304,7 +425,9 @@ func (hu *HuntAction) evaluateUtility(a Agent, env *Environment) float64 {
 				}
 			}
 
+			// Un animal isolé du troupeau est une cible plus facile
 			d := h.GetSprite().Position.DistanceTo(ani.GetSprite().Position)
+			d += float64(ani.GetHerdSize()-1) * StragglerBias
 			if d < minDist {
 				minDist = d
 				closest = ani
@@ -317,8 +440,20 @@ func (hu *HuntAction) evaluateUtility(a Agent, env *Environment) float64 {
 	}
 
 	hu.TargetID = closest.GetID()
+	hu.TargetPos = closest.GetSprite().Position
+	hu.needed = closest.GetPeopleNeeded()
 	
 	utility := (float64(h.hunger) * 1.5) - (minDist * 0.1)
+
+	// Répondre à un appel
+	if called[closest.GetID()] {
+		switch h.profile {
+		case Collectivist:
+			utility += 40
+		case Pragmatic:
+			utility += 20
+		}
+	}
 	risk := float64(closest.GetPeopleNeeded()) * 10
 
 	switch h.profile {
@@ -338,12 +473,25 @@ func (hu *HuntAction) evaluateUtility(a Agent, env *Environment) float64 {
 	return math.Max(0, utility)
 }
 
+// retreatHealth : en dessous de ce seuil, le chasseur blessé abandonne la chasse
+func retreatHealth(p Profile) int {
+	switch p {
+	case Cautious:
+		return 60
+	case Collectivist:
+		return 30
+	default:
+		return 40
+	}
+}
+
 type ReproduceAction struct {
 	MateID uint
 }
 
 func isPhysicallyReady(h *Human) bool {
-	return h.energy >= 400 && h.hunger <= 150
+	return h.GetLifeStage() == Adult && h.pregnancy == 0 && h.birthCooldown == 0 &&
+		h.energy >= 400 && h.hunger <= 150
 }
 
 func (r *ReproduceAction) Execute(a Agent, env *Environment) {
@@ -374,27 +522,18 @@ func (r *ReproduceAction) Execute(a Agent, env *Environment) {
 		}
 
 
-		if h.energy >= 250 && mate.energy >= 250 {
-			h.energy -= 250
-			mate.energy -= 250
-		} else {
-			h.currentAction = nil
-			mate.currentAction = nil
+		// C'est la femme qui conclut : une seule conception par rencontre
+		if h.sex != Female {
 			return
 		}
 
-		childProfile := determineChildProfile(h.profile, mate.profile)
-		childName := h.GetName() + "-Jr"
-		offsetX := (rand.Float64() * 20) - 10
-		offsetY := (rand.Float64() * 20) - 10
-		
-		newSprite := CreateSprite(h.GetSprite().Position.X+offsetX, h.GetSprite().Position.Y+offsetY, 16, 16)
-		
-		child := CreateHuman(childName, 100, newSprite, 20, 80, childProfile, "Child")
-		child.SetID(uint(rand.Uint32())) // ID Temporaire
-		
-		env.AddAgent(child)
-		child.Start(env)
+		if h.energy >= MatingEnergyCost && mate.energy >= MatingEnergyCost {
+			h.energy -= MatingEnergyCost
+			mate.energy -= MatingEnergyCost
+			h.expose(mate, MateTransmission)
+			mate.expose(h, MateTransmission)
+			conceive(h, mate, env)
+		}
 
 		h.currentAction = nil
 		mate.currentAction = nil
@@ -442,13 +581,33 @@ func (r *ReproduceAction) evaluateUtility(a Agent, env *Environment) float64 {
 		return 0.0
 	}
 
+	// Une proposition reçue rapproche virtuellement le partenaire
+	proposals := make(map[uint]bool)
+	for _, msg := range h.inbox {
+		if msg.Performative == Propose && msg.Topic == TopicMate {
+			proposals[msg.From] = true
+		}
+	}
+
 	var closestMate *Human
 	minDist := 99999.0
 
 	for _, ag := range h.visibleAgents {
 		if mate, ok := ag.(*Human); ok && mate.GetID() != h.GetID() {
-			if isPhysicallyReady(mate) {
+			if isPhysicallyReady(mate) && h.canMateWith(mate) {
+				// Le Prudent ne s'approche pas d'un partenaire malade
+				if h.profile == Cautious && mate.IsInfected() {
+					continue
+				}
+				trust := h.GetTrust(mate.GetID())
+				if trust < DistrustThreshold {
+					continue
+				}
 				d := h.GetSprite().Position.DistanceTo(mate.GetSprite().Position)
+				if proposals[mate.GetID()] {
+					d *= 0.5
+				}
+				d *= 1.5 - trust
 				if d < minDist {
 					minDist = d
 					closestMate = mate
@@ -470,4 +629,225 @@ func (r *ReproduceAction) evaluateUtility(a Agent, env *Environment) float64 {
 	utility += float64(visibleFood) * 10.0
 
 	return math.Max(0.0, utility)
-}
\ No newline at end of file
+}
+
+// ShareFoodAction : un chasseur apporte de la viande de sa carcasse à un humain affamé
+type ShareFoodAction struct {
+	CarcassID   uint
+	RecipientID uint
+
+	carried uint
+	loaded  bool
+}
+
+func (s *ShareFoodAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+
+	recipient, ok := env.findAgent(s.RecipientID).(*Human)
+	if !ok || !recipient.IsAlive() {
+		h.currentAction = nil
+		return
+	}
+
+	h.hunger += HungerCost
+	if h.hunger > MaxHunger { h.hunger = MaxHunger }
+
+	// Sans carcasse, on donne ce que l'on porte dans son sac
+	if !s.loaded && s.CarcassID == 0 {
+		s.carried = h.takeFood(recipient.hunger)
+		s.loaded = true
+		if s.carried == 0 {
+			h.currentAction = nil
+		}
+		return
+	}
+
+	if !s.loaded {
+		carcass, ok := env.findObject(s.CarcassID).(*Carcass)
+		if !ok || !carcass.IsAlive() {
+			h.currentAction = nil
+			return
+		}
+		if !moveTowards(a, carcass.GetSprite().Position, env) {
+			return
+		}
+		s.carried = carcass.Take(recipient.hunger)
+		s.loaded = true
+		if s.carried == 0 {
+			h.currentAction = nil
+		}
+		return
+	}
+
+	if moveTowards(a, recipient.GetSprite().Position, env) {
+		recipient.eat(s.carried)
+		recipient.adjustTrust(h.GetID(), TrustShare)
+		s.carried = 0
+		h.currentAction = nil
+	}
+}
+
+func (s *ShareFoodAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+
+	if h.profile == Selfish {
+		return 0.0
+	}
+
+	var carcass *Carcass
+	minDist := 99999.0
+	for _, obj := range h.visibleObjects {
+		if c, ok := obj.(*Carcass); ok && c.IsAlive() && c.IsHunter(h.GetID()) && c.GetMeat() > 0 {
+			d := h.GetSprite().Position.DistanceTo(c.GetSprite().Position)
+			if d < minDist {
+				minDist = d
+				carcass = c
+			}
+		}
+	}
+	if carcass == nil && h.load() == 0 {
+		return 0.0
+	}
+
+	// On nourrit en priorité les affamés à qui on fait confiance
+	var recipient *Human
+	bestScore := 0.0
+	for _, ag := range h.visibleAgents {
+		if other, ok := ag.(*Human); ok && other.IsAlive() && other.hunger > HungryThreshold && h.sameTribe(other) {
+			trust := h.GetTrust(other.GetID())
+			if trust < DistrustThreshold && h.profile != Collectivist {
+				continue
+			}
+			score := float64(other.hunger) * (0.5 + trust)
+			if other.GetLifeStage() == Child {
+				score *= ChildShareBonus
+			}
+			if score > bestScore {
+				bestScore = score
+				recipient = other
+			}
+		}
+	}
+	if recipient == nil {
+		return 0.0
+	}
+
+	s.CarcassID = 0
+	s.RecipientID = recipient.GetID()
+
+	dist := h.GetSprite().Position.DistanceTo(recipient.GetSprite().Position)
+	if carcass != nil {
+		s.CarcassID = carcass.GetID()
+		dist = minDist + carcass.GetSprite().Position.DistanceTo(recipient.GetSprite().Position)
+	}
+	utility := float64(recipient.hunger) - float64(h.hunger) - dist*0.1
+
+	switch h.profile {
+	case Collectivist:
+		utility *= 1.5
+	case Pragmatic:
+		utility *= 0.8
+	case Cautious:
+		utility *= 0.5
+	}
+
+	return math.Max(0.0, utility)
+}
+
+// FleeAction : s'éloigner d'un loup ou d'un agresseur. Le Collectiviste fuit vers ses semblables pour former un groupe.
+type FleeAction struct {
+	ThreatID uint
+
+	steps int
+}
+
+func (f *FleeAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+	f.steps++
+
+	threat := env.findAgent(f.ThreatID)
+	if threat == nil || !threat.IsAlive() || f.steps > RetreatDuration {
+		h.currentAction = nil
+		return
+	}
+
+	pos := h.GetSprite().Position
+	tPos := threat.GetSprite().Position
+	if pos.DistanceTo(tPos) > DangerRadius*1.5 {
+		h.currentAction = nil
+		return
+	}
+
+	h.hunger += HungerCost
+	if h.hunger > MaxHunger { h.hunger = MaxHunger }
+
+	dx := pos.X - tPos.X
+	dy := pos.Y - tPos.Y
+
+	if h.profile == Collectivist {
+		if ally := h.closestHuman(); ally != nil {
+			aPos := ally.GetSprite().Position
+			if pos.DistanceTo(aPos) > GroupRadius/2 {
+				dx = aPos.X - pos.X
+				dy = aPos.Y - pos.Y
+			}
+		}
+	}
+
+	length := math.Sqrt(dx*dx + dy*dy)
+	if length > 0 {
+		h.Move(dx/length*h.speed(), dy/length*h.speed(), env)
+	}
+}
+
+func (f *FleeAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	var threat Agent
+	if h.closestPredator != nil {
+		threat = h.closestPredator
+	} else if h.attacker != nil {
+		threat = h.attacker
+	} else {
+		return 0.0
+	}
+	f.ThreatID = threat.GetID()
+
+	dist := h.GetSprite().Position.DistanceTo(threat.GetSprite().Position)
+	utility := (DangerRadius-dist)*3 + float64(MaxHealth-h.health)
+
+	allies := 0
+	for _, ag := range h.visibleAgents {
+		if other, ok := ag.(*Human); ok && other.GetSprite().Position.DistanceTo(h.GetSprite().Position) <= GroupRadius {
+			allies++
+		}
+	}
+
+	switch h.profile {
+	case Cautious:
+		utility *= 2
+	case Collectivist:
+		utility *= 1.2
+	case Pragmatic:
+		// En groupe, la meute n'attaque pas : inutile de fuir
+		if allies+1 >= PredatorRepelGroup {
+			utility *= 0.3
+		}
+	}
+
+	return math.Max(0.0, utility)
+}
+
+func (h *Human) closestHuman() *Human {
+	var closest *Human
+	minDist := math.Inf(1)
+	for _, ag := range h.visibleAgents {
+		if other, ok := ag.(*Human); ok && other.IsAlive() {
+			d := h.GetSprite().Position.DistanceTo(other.GetSprite().Position)
+			if d < minDist {
+				minDist = d
+				closest = other
+			}
+		}
+	}
+	return closest
+}
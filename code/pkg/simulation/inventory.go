@@ -0,0 +1,257 @@
+package simulation
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	InventoryCapacity = 200  // nourriture transportable par un adulte
+	PlantShelfLife    = 2400 // ticks avant qu'une plante cueillie ne pourrisse
+	MeatShelfLife     = 1200 // ticks avant que la viande transportée ne pourrisse
+	CarryUtility      = 40.0 // envie de remplir un sac vide quand on n'a pas faim
+	EatFromBagFactor  = 1.5  // manger ce qu'on porte évite de se déplacer
+)
+
+type ItemKind int
+
+const (
+	PlantItem ItemKind = iota
+	MeatItem
+	CookedMeatItem
+	ItemKinds
+)
+
+func (k ItemKind) String() string {
+	switch k {
+	case MeatItem:
+		return "viande"
+	case CookedMeatItem:
+		return "cuite"
+	default:
+		return "plantes"
+	}
+}
+
+// shelfLife : la viande cuite se conserve deux fois plus longtemps que la crue
+func (k ItemKind) shelfLife() int {
+	switch k {
+	case MeatItem:
+		return MeatShelfLife
+	case CookedMeatItem:
+		return MeatShelfLife * 2
+	default:
+		return PlantShelfLife
+	}
+}
+
+// Item : une portion de nourriture transportée, qui pourrit avec l'âge
+type Item struct {
+	Kind   ItemKind
+	Amount uint
+	Age    int
+}
+
+// inventory : sac d'un humain, partagé avec les autres goroutines (partage de chasse, dons)
+type inventory struct {
+	items     []Item
+	spoiled   uint
+	materials [MaterialKinds]int
+	tools     [ToolKinds]int  // usure restante, 0 : pas d'outil
+	received  [ItemKinds]uint // reçu d'un troc, rangé par le propriétaire à sa perception
+	mutex     sync.Mutex
+}
+
+// capacity : un enfant porte moitié moins, un panier augmente la place
+func (h *Human) capacity() uint {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	return h.capacityLocked()
+}
+
+func (h *Human) capacityLocked() uint {
+	capacity := uint(InventoryCapacity)
+	if h.GetLifeStage() == Child {
+		capacity /= 2
+	}
+	if h.bag.tools[Basket] > 0 {
+		capacity += BasketCapacity
+	}
+	return capacity
+}
+
+func (inv *inventory) loadLocked() uint {
+	total := uint(0)
+	for _, it := range inv.items {
+		total += it.Amount
+	}
+	for _, amount := range inv.received {
+		total += amount
+	}
+	return total
+}
+
+// load renvoie la quantité de nourriture transportée
+func (h *Human) load() uint {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	return h.bag.loadLocked()
+}
+
+// freeSpace renvoie la place restante dans le sac
+func (h *Human) freeSpace() uint {
+	if load := h.load(); load < h.capacity() {
+		return h.capacity() - load
+	}
+	return 0
+}
+
+// carry range au plus amount dans le sac et renvoie la quantité qui n'a pas tenu
+func (h *Human) carry(kind ItemKind, amount uint) uint {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	free := uint(0)
+	if load := h.bag.loadLocked(); load < h.capacityLocked() {
+		free = h.capacityLocked() - load
+	}
+	kept := amount
+	if kept > free {
+		kept = free
+	}
+	if kept > 0 {
+		h.bag.items = append(h.bag.items, Item{Kind: kind, Amount: kept})
+	}
+	return amount - kept
+}
+
+// collect : on mange ce dont on a besoin, on garde le reste si le sac le permet
+func (h *Human) collect(kind ItemKind, amount uint) {
+	eaten := amount
+	if eaten > h.hunger {
+		eaten = h.hunger
+	}
+	h.eat(eaten)
+	h.carry(kind, amount-eaten)
+}
+
+// receive : un autre humain nous remet de la nourriture, on ne touche pas à notre faim depuis sa goroutine
+func (h *Human) receive(kind ItemKind, amount uint) {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	h.bag.received[kind] += amount
+}
+
+// collectReceived : ce que l'on a reçu est mangé ou rangé par soi-même
+func (h *Human) collectReceived() {
+	h.bag.mutex.Lock()
+	received := h.bag.received
+	h.bag.received = [ItemKinds]uint{}
+	h.bag.mutex.Unlock()
+	for kind, amount := range received {
+		if amount > 0 {
+			h.collect(ItemKind(kind), amount)
+		}
+	}
+}
+
+// takeFood retire au plus amount du sac, les portions les plus anciennes d'abord
+func (h *Human) takeFood(amount uint) uint {
+	return h.bag.remove(amount, func(ItemKind) bool { return true })
+}
+
+// takeItem retire au plus amount d'une seule sorte d'objet
+func (h *Human) takeItem(kind ItemKind, amount uint) uint {
+	return h.bag.remove(amount, func(k ItemKind) bool { return k == kind })
+}
+
+// holding renvoie la quantité transportée d'une sorte d'objet
+func (h *Human) holding(kind ItemKind) uint {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	total := uint(0)
+	for _, it := range h.bag.items {
+		if it.Kind == kind {
+			total += it.Amount
+		}
+	}
+	return total
+}
+
+func (inv *inventory) remove(amount uint, match func(ItemKind) bool) uint {
+	inv.mutex.Lock()
+	defer inv.mutex.Unlock()
+	taken := uint(0)
+	kept := []Item{}
+	for _, it := range inv.items {
+		if taken < amount && match(it.Kind) {
+			part := amount - taken
+			if part > it.Amount {
+				part = it.Amount
+			}
+			it.Amount -= part
+			taken += part
+		}
+		if it.Amount > 0 {
+			kept = append(kept, it)
+		}
+	}
+	inv.items = kept
+	return taken
+}
+
+// spoilFood vieillit le contenu du sac et jette ce qui a pourri
+func (h *Human) spoilFood() {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	kept := h.bag.items[:0]
+	for _, it := range h.bag.items {
+		it.Age++
+		if it.Age >= it.Kind.shelfLife() {
+			h.bag.spoiled += it.Amount
+			continue
+		}
+		kept = append(kept, it)
+	}
+	h.bag.items = kept
+}
+
+// GetInventory renvoie une copie du contenu du sac
+func (h *Human) GetInventory() []Item {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	return append([]Item{}, h.bag.items...)
+}
+
+// GetInventorySummary décrit le sac pour l'inspecteur
+func (h *Human) GetInventorySummary() string {
+	totals := [ItemKinds]uint{}
+	for _, it := range h.GetInventory() {
+		totals[it.Kind] += it.Amount
+	}
+	return fmt.Sprintf("%d/%d (pl. %d, vi. %d, cuite %d)", totals[PlantItem]+totals[MeatItem]+totals[CookedMeatItem], h.capacity(),
+		totals[PlantItem], totals[MeatItem], totals[CookedMeatItem])
+}
+
+// GetSpoiledFood renvoie la nourriture perdue car pourrie dans le sac
+func (h *Human) GetSpoiledFood() uint {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	return h.bag.spoiled
+}
+
+// EatAction : manger ce que l'on transporte
+type EatAction struct{}
+
+func (e *EatAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+	h.eat(h.takeFood(h.hunger))
+	h.currentAction = nil
+}
+
+func (e *EatAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.hunger <= HungryThreshold/2 || h.load() == 0 {
+		return 0.0
+	}
+	return float64(h.hunger) * EatFromBagFactor
+}
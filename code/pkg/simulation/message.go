@@ -0,0 +1,91 @@
+package simulation
+
+const (
+	CommunicationRange = 300.0
+	InformCooldown     = 60
+)
+
+// Performative donne l'intention d'un message (actes de langage)
+type Performative int
+
+const (
+	Inform Performative = iota
+	Request
+	Propose
+	Accept
+	Reject
+	CallForProposal
+)
+
+// MessageTopic précise le sujet du message
+type MessageTopic int
+
+const (
+	TopicHunt MessageTopic = iota
+	TopicFood
+	TopicHelp
+	TopicMate
+	TopicTrade
+)
+
+// BroadcastID : destinataire "tout le monde à portée"
+const BroadcastID uint = 0
+
+type Message struct {
+	From         uint
+	To           uint
+	Performative Performative
+	Topic        MessageTopic
+	TargetID     uint
+	Pos          Position
+	Value        float64
+	ContractID   uint
+
+	// Troc : objets échangés et tour de négociation (Value porte le prix)
+	Offer TradeOffer
+
+	// Position de l'émetteur au moment de l'envoi (portée limitée)
+	origin Position
+}
+
+func CreateMessage(from, to uint, perf Performative, topic MessageTopic, targetID uint, pos Position) Message {
+	return Message{
+		From:         from,
+		To:           to,
+		Performative: perf,
+		Topic:        topic,
+		TargetID:     targetID,
+		Pos:          pos,
+	}
+}
+
+func (m Message) IsBroadcast() bool {
+	return m.To == BroadcastID
+}
+
+// Send place un message dans la boîte d'envoi, il sera distribué au tick suivant
+func (ap *AgentParams) Send(msg Message) {
+	msg.From = ap.id
+	msg.origin = ap.sprite.Position
+	ap.outbox = append(ap.outbox, msg)
+}
+
+// Receive est appelé par l'environnement entre deux ticks
+func (ap *AgentParams) Receive(msg Message) {
+	ap.mailbox = append(ap.mailbox, msg)
+}
+
+// ReadMessages vide la boîte aux lettres
+func (ap *AgentParams) ReadMessages() []Message {
+	msgs := ap.mailbox
+	ap.mailbox = nil
+	return msgs
+}
+
+func (ap *AgentParams) flushOutbox(env *Environment) {
+	if len(ap.outbox) == 0 {
+		return
+	}
+	env.PostMessages(ap.outbox)
+	ap.outbox = nil
+}
@@ -0,0 +1,325 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	PredatorVisionRadius   = 180.0
+	PredatorSpeed          = 1.6
+	PredatorAttack         = 8
+	PredatorAttackCooldown = 20
+	PredatorMaxHunger      = 800
+	PredatorRepelGroup     = 3
+	GroupRadius            = 60.0
+	PackRadius             = 80.0
+	RetreatDuration        = 120
+	DangerRadius           = 150.0
+	PackImmigrationRate    = 1.0 / (4 * DayLength) // nouvelles meutes par tick, tant qu'il en manque
+)
+
+type PredatorState int
+
+const (
+	PredatorRoam PredatorState = iota
+	PredatorHunt
+	PredatorRetreat
+)
+
+// Predator : un loup, qui chasse en meute les humains isolés et les poulets
+type Predator struct {
+	AgentParams
+	packID         int
+	hunger         uint
+	state          PredatorState
+	stepsInState   int
+	attackCooldown int
+
+	target    Agent
+	targetID  uint
+	targetPos Position
+
+	// Perception
+	prey       []Agent
+	humans     []Agent
+	packmates  []*Predator
+	packTarget Agent
+	night      bool
+	fires      []Position
+}
+
+func CreatePredator(name string, sprite Sprite, packID int) *Predator {
+	return &Predator{
+		AgentParams: NewAgentParams(0, name, 80, sprite),
+		packID:      packID,
+		state:       PredatorRoam,
+	}
+}
+
+func (p *Predator) Start(env *Environment) {
+	go func() {
+		for {
+			select {
+			case <-p.syncChan:
+				if p.alive {
+					p.Percept(env)
+					p.Deliberate()
+					p.Act(env)
+				}
+				p.doneChan <- true
+			case <-p.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+func (p *Predator) GetPackID() int          { return p.packID }
+func (p *Predator) GetHunger() uint         { return p.hunger }
+func (p *Predator) GetState() PredatorState { return p.state }
+
+func (p *Predator) Percept(env *Environment) {
+	p.prey = []Agent{}
+	p.humans = []Agent{}
+	p.packmates = []*Predator{}
+	p.packTarget = nil
+	pos := p.GetSprite().Position
+	packTargetID := uint(0)
+
+	// La nuit ne gêne pas la vue du loup, contrairement à ses proies
+	p.night = env.isNight()
+
+	p.fires = []Position{}
+	for _, o := range env.objects {
+		if f, ok := o.(*Fire); ok && f.IsAlive() && pos.DistanceTo(f.GetSprite().Position) < PredatorVisionRadius {
+			p.fires = append(p.fires, f.GetSprite().Position)
+		}
+	}
+
+	for _, a := range env.agents {
+		if !a.IsAlive() || a.GetID() == p.GetID() {
+			continue
+		}
+		dist := pos.DistanceTo(a.GetSprite().Position)
+
+		switch other := a.(type) {
+		case *Predator:
+			if other.packID == p.packID {
+				p.packmates = append(p.packmates, other)
+				if other.state == PredatorHunt && other.targetID != 0 {
+					packTargetID = other.targetID
+				}
+			}
+		case *Human:
+			if dist < PredatorVisionRadius {
+				p.humans = append(p.humans, other)
+				p.prey = append(p.prey, other)
+			}
+		case *Animal:
+			if other.GetType() == Chicken && dist < PredatorVisionRadius {
+				p.prey = append(p.prey, other)
+			}
+		}
+	}
+
+	if packTargetID != 0 {
+		if t := env.findAgent(packTargetID); t != nil && t.IsAlive() {
+			p.packTarget = t
+		}
+	}
+}
+
+func (p *Predator) Deliberate() {
+	p.stepsInState++
+	if p.attackCooldown > 0 {
+		p.attackCooldown--
+	}
+
+	// Un groupe d'humains fait fuir la meute
+	if p.state == PredatorRetreat && p.stepsInState < RetreatDuration {
+		return
+	}
+	if p.isRepelled() {
+		p.setState(PredatorRetreat)
+		p.setTarget(nil)
+		return
+	}
+
+	if p.target != nil && p.target.IsAlive() && p.isVulnerable(p.target) {
+		return
+	}
+	p.setTarget(nil)
+
+	// On suit d'abord la cible de la meute, sinon la proie vulnérable la plus proche
+	if p.packTarget != nil && p.isVulnerable(p.packTarget) {
+		p.setTarget(p.packTarget)
+		p.setState(PredatorHunt)
+		return
+	}
+
+	// Le jour, la meute ne chasse que si elle est affamée
+	if p.hunger > PredatorMaxHunger/4 && (p.night || p.hunger > PredatorDayHunger) {
+		minDist := math.Inf(1)
+		for _, prey := range p.prey {
+			if !p.isVulnerable(prey) {
+				continue
+			}
+			d := p.GetSprite().Position.DistanceTo(prey.GetSprite().Position)
+			if d < minDist {
+				minDist = d
+				p.setTarget(prey)
+			}
+		}
+	}
+
+	if p.target != nil {
+		p.setState(PredatorHunt)
+	} else if p.state != PredatorRoam {
+		p.setState(PredatorRoam)
+	}
+}
+
+func (p *Predator) setTarget(t Agent) {
+	p.target = t
+	p.targetID = 0
+	if t != nil {
+		p.targetID = t.GetID()
+	}
+}
+
+func (p *Predator) setState(s PredatorState) {
+	if p.state != s {
+		p.state = s
+		p.stepsInState = 0
+	}
+}
+
+// isVulnerable : un humain n'est une proie que s'il est isolé, hors d'un abri et loin du feu
+func (p *Predator) isVulnerable(prey Agent) bool {
+	h, ok := prey.(*Human)
+	if !ok {
+		return true
+	}
+	if h.sheltered || nearFire(h.GetSprite().Position, p.fires, FireRadius) {
+		return false
+	}
+	return countHumansAround(prey.GetSprite().Position, p.humans) < 2
+}
+
+// isRepelled : un groupe d'humains ou un feu fait fuir la meute
+func (p *Predator) isRepelled() bool {
+	return countHumansAround(p.GetSprite().Position, p.humans) >= PredatorRepelGroup ||
+		nearFire(p.GetSprite().Position, p.fires, FireScareRadius)
+}
+
+func countHumansAround(pos Position, humans []Agent) int {
+	count := 0
+	for _, h := range humans {
+		if h.IsAlive() && pos.DistanceTo(h.GetSprite().Position) <= GroupRadius {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *Predator) Act(env *Environment) {
+	p.hunger++
+	if p.hunger >= PredatorMaxHunger {
+		p.hunger = PredatorMaxHunger
+		p.IsAttacked(1)
+		if !p.IsAlive() {
+			return
+		}
+	}
+
+	pos := p.GetSprite().Position
+
+	switch p.state {
+	case PredatorRetreat:
+		var fx, fy float64
+		for _, h := range p.humans {
+			hPos := h.GetSprite().Position
+			fx += pos.X - hPos.X
+			fy += pos.Y - hPos.Y
+		}
+		for _, fPos := range p.fires {
+			fx += pos.X - fPos.X
+			fy += pos.Y - fPos.Y
+		}
+		p.moveDir(fx, fy, PredatorSpeed, env)
+
+	case PredatorHunt:
+		if p.target == nil {
+			return
+		}
+		tPos := p.target.GetSprite().Position
+		if pos.DistanceTo(tPos) <= ActionRange*1.5 {
+			p.attack(env)
+			return
+		}
+		p.moveDir(tPos.X-pos.X, tPos.Y-pos.Y, PredatorSpeed, env)
+
+	case PredatorRoam:
+		// La meute reste groupée
+		if len(p.packmates) > 0 {
+			var cx, cy float64
+			for _, mate := range p.packmates {
+				cx += mate.GetSprite().Position.X
+				cy += mate.GetSprite().Position.Y
+			}
+			cx /= float64(len(p.packmates))
+			cy /= float64(len(p.packmates))
+			if pos.DistanceTo(Position{X: cx, Y: cy}) > PackRadius {
+				p.moveDir(cx-pos.X, cy-pos.Y, AnimalSpeed, env)
+				return
+			}
+		}
+		if pos.DistanceTo(p.targetPos) < 5.0 || p.stepsInState > WanderDuration*2 {
+			p.stepsInState = 0
+			p.targetPos = Position{
+				X: rand.Float64() * float64(env.width),
+				Y: rand.Float64() * float64(env.height),
+			}
+		}
+		p.moveDir(p.targetPos.X-pos.X, p.targetPos.Y-pos.Y, AnimalSpeed, env)
+	}
+}
+
+func (p *Predator) attack(env *Environment) {
+	if p.attackCooldown > 0 {
+		return
+	}
+	p.attackCooldown = PredatorAttackCooldown
+
+	switch prey := p.target.(type) {
+	case *Animal:
+		prey.Kill()
+		p.eat(prey.GetHungerValue())
+		p.setTarget(nil)
+	case *Human:
+		prey.IsAttacked(PredatorAttack)
+		if prey.IsAlive() {
+			env.LogEvent(EventInjury, "%s blesse par un loup (-%d)", prey.GetName(), PredatorAttack)
+		} else {
+			env.LogEvent(EventDeath, "%s tue par un loup", prey.GetName())
+			p.eat(PredatorMaxHunger)
+			p.setTarget(nil)
+		}
+	}
+}
+
+func (p *Predator) eat(val uint) {
+	if p.hunger < val {
+		p.hunger = 0
+	} else {
+		p.hunger -= val
+	}
+}
+
+func (p *Predator) moveDir(dx, dy, speed float64, env *Environment) {
+	length := math.Sqrt(dx*dx + dy*dy)
+	if length > 0 {
+		p.Move(dx/length*speed, dy/length*speed, env)
+	}
+}
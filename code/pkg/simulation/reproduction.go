@@ -0,0 +1,156 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	MatingEnergyCost       = 100
+	PregnancyEnergyDrain   = 2 // énergie perdue en plus toutes les 30 ticks par la future mère
+	PregnancyHungerPenalty = 1
+	PartnerDistance        = 60.0
+)
+
+type Sex int
+
+const (
+	Male Sex = iota
+	Female
+)
+
+func (s Sex) String() string {
+	if s == Female {
+		return "Femme"
+	}
+	return "Homme"
+}
+
+// MatingRules : règles de reproduction humaine, réglables dans le fichier de scénario
+type MatingRules struct {
+	MaleRatio     float64 `json:"maleRatio"`
+	Gestation     int     `json:"gestation"`     // ticks de grossesse
+	BirthCooldown int     `json:"birthCooldown"` // ticks avant une nouvelle grossesse
+	PairBonding   bool    `json:"pairBonding"`   // les partenaires restent ensemble
+}
+
+func DefaultMatingRules() MatingRules {
+	return MatingRules{MaleRatio: 0.5, Gestation: 900, BirthCooldown: 1800, PairBonding: true}
+}
+
+// valid ramène les règles dans des bornes sensées (grossesse d'au moins un tick, proportion entre 0 et 1)
+func (m MatingRules) valid() MatingRules {
+	m.MaleRatio = math.Max(0, math.Min(1, m.MaleRatio))
+	if m.Gestation < 1 {
+		m.Gestation = 1
+	}
+	if m.BirthCooldown < 0 {
+		m.BirthCooldown = 0
+	}
+	return m
+}
+
+func (e *Environment) randomSex() Sex {
+	if rand.Float64() < e.mating.MaleRatio {
+		return Male
+	}
+	return Female
+}
+
+func (h *Human) GetSex() Sex {
+	return h.sex
+}
+
+// GetPregnancy renvoie le nombre de ticks avant la naissance (0 si pas enceinte)
+func (h *Human) GetPregnancy() int {
+	return h.pregnancy
+}
+
+func (h *Human) GetPartnerID() uint {
+	return h.partnerID
+}
+
+// canMateWith : sexes opposés, même tribu et, avec les couples, fidélité au partenaire vivant
+func (h *Human) canMateWith(mate *Human) bool {
+	if mate.sex == h.sex || !h.sameTribe(mate) {
+		return false
+	}
+	if !h.pairBonding {
+		return true
+	}
+	if h.partner != nil && h.partner.IsAlive() && h.partner != mate {
+		return false
+	}
+	return mate.partner == nil || !mate.partner.IsAlive() || mate.partner == h
+}
+
+// conceive : la femme tombe enceinte, le couple se forme si les règles le prévoient
+func conceive(mother, father *Human, env *Environment) {
+	mother.pregnancy = env.mating.Gestation
+	mother.father = father
+	if env.mating.PairBonding {
+		mother.partner, mother.partnerID = father, father.GetID()
+		father.partner, father.partnerID = mother, mother.GetID()
+	}
+}
+
+// progressPregnancy : la grossesse coûte de l'énergie et de la nourriture, puis l'enfant naît
+func (h *Human) progressPregnancy(env *Environment) {
+	if h.birthCooldown > 0 {
+		h.birthCooldown--
+	}
+	if h.pregnancy == 0 {
+		return
+	}
+	if h.tickCounter == 0 {
+		if h.energy >= PregnancyEnergyDrain {
+			h.energy -= PregnancyEnergyDrain
+		} else {
+			h.energy = 0
+		}
+		h.hunger += PregnancyHungerPenalty
+		if h.hunger > MaxHunger {
+			h.hunger = MaxHunger
+		}
+	}
+	h.pregnancy--
+	if h.pregnancy == 0 {
+		h.giveBirth(env)
+		h.birthCooldown = env.mating.BirthCooldown
+	}
+}
+
+func (h *Human) giveBirth(env *Environment) {
+	father := h.father
+	h.father = nil
+
+	childProfile := determineChildProfile(h.profile, father.profile)
+	childName := h.GetName() + "-Jr"
+	offsetX := (rand.Float64() * 20) - 10
+	offsetY := (rand.Float64() * 20) - 10
+
+	newSprite := CreateSprite(h.GetSprite().Position.X+offsetX, h.GetSprite().Position.Y+offsetY, 16, 16)
+
+	child := CreateHuman(childName, ChildHealth, newSprite, 20, 80, childProfile, "Child")
+	child.SetID(uint(rand.Uint32())) // ID Temporaire
+	child.sex = env.randomSex()
+	child.tribe = h.tribe
+	adopt(child, h, father)
+	env.families.record(child, func(c *ChildSurvival) { c.Births++ })
+
+	env.AddAgent(child)
+	child.Start(env)
+}
+
+// joinPartner : au repos ou sans occupation, on rejoint son partenaire
+func (h *Human) joinPartner(env *Environment) {
+	if h.partner == nil || !h.partner.IsAlive() {
+		return
+	}
+	if _, resting := h.currentAction.(*RestAction); !resting && h.currentAction != nil {
+		return
+	}
+	if h.GetSprite().Position.DistanceTo(h.partner.GetSprite().Position) > PartnerDistance {
+		moveTowards(h, h.partner.GetSprite().Position, env)
+	}
+}
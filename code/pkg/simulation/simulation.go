@@ -11,11 +11,28 @@ type TurnData struct {
 	Tick              int
 	HumansAlive       int
 	AnimalsAlive      int
+	PredatorsAlive    int
 	VegetablesAlive   int
 	CountPragmatic    int
 	CountCautious     int
 	CountSelfish      int
 	CountCollectivist int
+	CooperationRate   float64
+	GiniFood          float64
+	Day               int
+	Season            Season
+	Disasters         uint8 // bit i : catastrophe DisasterKind(i) en cours
+	Susceptible       int
+	Infected          int
+	Recovered         int
+	Children          int
+	Elders            int
+	Tribes            TribeStats
+	TerritoryCells    [MaxTribes]int // cases possédées par chaque tribu
+	Fights            int            // coups portés entre tribus pendant le tick
+	FightDeaths       int
+	Trade             TradeStats
+	ToolOwners        [ProfileCount][ToolKinds]int // humains équipés de chaque outil, par profil
 }
 
 type Simulation struct {
@@ -37,8 +54,25 @@ type Simulation struct {
 	InitAnimals   int
 	InitPlants    int
 
-	nextAnimalTime float64
-	nextPlantTime  float64
+	// Meutes de loups
+	PredatorPacks int
+	PackSize      int
+
+	// Apparition spontanée des animaux (processus de Poisson)
+	AnimalSpawning bool
+
+	// Tribus (vide : une seule population sur toute la carte)
+	Tribes []TribeConfig
+
+	nextAnimalTime   float64
+	nextPlantTime    float64
+	nextMaterialTime float64
+	nextPackTime     float64
+	packSeq          int
+
+	// Taux d'apparition avec lesquels les prochaines attentes ont été tirées
+	animalRate float64
+	plantRate  float64
 
 	// Probabilités cumulatives pour les profils
 	distPragmatic    float64
@@ -46,25 +80,33 @@ type Simulation struct {
 	distSelfish      float64
 	distCollectivist float64
 
-	History         []TurnData
-	globalIDCounter uint
+	History []TurnData
+
+	// Catastrophes possibles (fichier de scénario)
+	scenario Scenario
+
+	// Module de théorie des jeux (nil si désactivé)
+	interactions *InteractionModule
 }
 
 func CreateSimulation(width, height int) *Simulation {
 	rand.Seed(time.Now().UnixNano())
-	return &Simulation{
-		maxSteps:        5000,
-		MaxAnimals:      100,
-		MaxPlants:       100,
-		currentStep:     0,
-		isRunning:       false,
-		agents:          []Agent{},
-		environment:     CreateEnvironment(width, height),
-		History:         []TurnData{},
-		globalIDCounter: 0,
-		nextAnimalTime:  0,
-		nextPlantTime:   0,
+	s := &Simulation{
+		maxSteps:       5000,
+		MaxAnimals:     100,
+		MaxPlants:      100,
+		currentStep:    0,
+		isRunning:      false,
+		agents:         []Agent{},
+		environment:    CreateEnvironment(width, height),
+		History:        []TurnData{},
+		nextAnimalTime: 0,
+		nextPlantTime:  0,
+		AnimalSpawning: true,
 	}
+	s.environment.maxAnimals = s.MaxAnimals
+	s.SetScenario(DefaultScenario())
+	return s
 }
 
 // Mise à jour pour inclure MaxSteps et les 4 Poids
@@ -78,6 +120,7 @@ func (s *Simulation) SetParameters(maxSteps, maxAnimals, maxPlants int, lambdaAn
 	
 	// Enregistrement des limites
 	s.MaxAnimals = maxAnimals
+	s.environment.maxAnimals = maxAnimals
 	s.MaxPlants = maxPlants
 
 	// Calcul des probabilités cumulatives
@@ -95,6 +138,32 @@ func (s *Simulation) SetParameters(maxSteps, maxAnimals, maxPlants int, lambdaAn
 	s.nextPlantTime = s.getExponentialTime(s.lambdaPlants)
 }
 
+// SetPredators règle la densité de prédateurs (nombre de meutes et taille)
+func (s *Simulation) SetPredators(packs, packSize int) {
+	s.PredatorPacks = packs
+	s.PackSize = packSize
+}
+
+// SetAnimalDynamics active la reproduction des animaux et/ou l'apparition spontanée
+func (s *Simulation) SetAnimalDynamics(breeding, spawning bool) {
+	s.environment.animalBreeding = breeding
+	s.AnimalSpawning = spawning
+}
+
+// SetInteractionGame active le module d'interactions avec le jeu choisi
+func (s *Simulation) SetInteractionGame(typ GameType) {
+	game := CreateGame(typ)
+	if game == nil {
+		s.interactions = nil
+		return
+	}
+	s.interactions = CreateInteractionModule(game)
+}
+
+func (s *Simulation) GetInteractions() *InteractionModule {
+	return s.interactions
+}
+
 func (s *Simulation) getExponentialTime(lambda float64) float64 {
 	if lambda <= 0 { return math.Inf(1) }
 	u := rand.Float64()
@@ -134,29 +203,46 @@ func (s *Simulation) Start() {
 	s.isRunning = true
 	
 	// Spawn Humains
-	safeW := float64(s.environment.width - 16)
-	safeH := float64(s.environment.height - 16)
-
 	for i := 0; i < s.InitHumans; i++ {
-		profile := s.PickRandomProfile()
-		
-		x := rand.Float64() * safeW
-		y := rand.Float64() * safeH
-		
-		pos := CreatePosition(x, y)
-		sprite := CreateSprite(pos.X, pos.Y, 16, 16)
-		name := fmt.Sprintf("H-%d", i)
-		h := CreateHuman(name, 100, sprite, 50, 100, profile, "Base")
+		h := s.spawnHuman(i)
+		// Les humains de départ sont des adultes d'âges variés
+		h.age = AdultAge + rand.Intn(ElderAge-AdultAge)
+		h.sex = s.environment.randomSex()
 		s.AddAgent(h)
 	}
+
+	// Premiers malades : la maladie circule sans attendre une épidémie
+	sick := 0
+	for _, i := range rand.Perm(len(s.environment.agents)) {
+		h, ok := s.environment.agents[i].(*Human)
+		if sick >= s.scenario.Infected {
+			break
+		}
+		if ok && h.infect() {
+			sick++
+		}
+	}
 	
 	for i := 0; i < s.InitAnimals; i++ {
 		s.spawnAnimal()
 	}
 
+	// Les plantes de départ sont déjà mûres
 	for i := 0; i < s.InitPlants; i++ {
-		s.spawnVegetable()
+		if veg := s.spawnVegetable(); veg != nil {
+			veg.growth = float64(veg.typ.growthDuration())
+		}
+	}
+
+	for i := 0; i < InitMaterials; i++ {
+		s.spawnMaterial()
+	}
+
+	for s.packSeq < s.PredatorPacks {
+		s.spawnPack(s.packSeq)
+		s.packSeq++
 	}
+	s.nextPackTime = s.getExponentialTime(PackImmigrationRate)
 
 	for _, a := range s.environment.agents {
 		a.Start(&s.environment)
@@ -171,8 +257,7 @@ func (s *Simulation) Stop() {
 }
 
 func (s *Simulation) AddAgent(agent Agent) {
-	s.globalIDCounter++
-	agent.SetID(s.globalIDCounter)
+	agent.SetID(s.environment.newID())
 	s.environment.AddAgent(agent)
 	if s.isRunning {
 		agent.Start(&s.environment)
@@ -183,11 +268,13 @@ func (s *Simulation) Step() {
 	if !s.isRunning { return }
 
 	s.currentStep++
+	s.environment.tick = s.currentStep
 	if s.maxSteps > 0 && s.currentStep >= s.maxSteps {
 		s.Stop()
 		fmt.Println("Simulation terminée (Temps).")
 		return
 	}
+	s.updateDisasters()
 
 	activeAgents := make([]Agent, 0)
 	for _, a := range s.environment.agents {
@@ -199,6 +286,24 @@ func (s *Simulation) Step() {
 	for _, a := range activeAgents { a.Sync() <- true }
 	for _, a := range activeAgents { <-a.Done() }
 
+	s.environment.DeliverMessages()
+	s.environment.expireContracts()
+	for _, o := range s.environment.objects {
+		switch obj := o.(type) {
+		case *Carcass:
+			obj.Rot()
+		case *Vegetable:
+			obj.Grow(s.environment.weather())
+		case *Camp:
+			obj.Decay()
+		case *Fire:
+			obj.Burn()
+		}
+	}
+	if s.interactions != nil {
+		s.interactions.Play(&s.environment)
+	}
+	s.environment.updateTerritory()
 	s.ManageSpawns()
 	s.environment.RemoveDeadAgents()
 	s.environment.RemoveDeadObjects()
@@ -207,12 +312,25 @@ func (s *Simulation) Step() {
 
 func (s *Simulation) ManageSpawns() {
 	maxSpawnsPerTick := 5
+	season := s.environment.weather()
+
+	// Processus sans mémoire : à chaque changement de saison ou de catastrophe, on retire l'attente au nouveau taux
+	// Un taux nul donne une attente infinie jusqu'au prochain changement
+	if rate := s.lambdaAnimals * season.AnimalSpawn; rate != s.animalRate {
+		s.animalRate = rate
+		s.nextAnimalTime = s.getExponentialTime(rate)
+	}
+	if rate := s.lambdaPlants * season.PlantSpawn; rate != s.plantRate {
+		s.plantRate = rate
+		s.nextPlantTime = s.getExponentialTime(rate)
+	}
+
 	s.nextAnimalTime -= 1.0
 	c := 0
-	for s.nextAnimalTime <= 0 {
+	for s.AnimalSpawning && s.nextAnimalTime <= 0 {
 		if c >= maxSpawnsPerTick { break }
 		s.spawnAnimal()
-		s.nextAnimalTime += s.getExponentialTime(s.lambdaAnimals)
+		s.nextAnimalTime += s.getExponentialTime(s.animalRate)
 		c++
 	}
 	s.nextPlantTime -= 1.0
@@ -220,9 +338,34 @@ func (s *Simulation) ManageSpawns() {
 	for s.nextPlantTime <= 0 {
 		if c >= maxSpawnsPerTick { break }
 		s.spawnVegetable()
-		s.nextPlantTime += s.getExponentialTime(s.lambdaPlants)
+		s.nextPlantTime += s.getExponentialTime(s.plantRate)
 		c++
 	}
+	s.nextMaterialTime -= 1.0
+	for s.nextMaterialTime <= 0 {
+		s.spawnMaterial()
+		s.nextMaterialTime += s.getExponentialTime(MaterialRate)
+	}
+	// Une meute disparue est remplacée par une autre venue d'ailleurs
+	s.nextPackTime -= 1.0
+	if s.nextPackTime <= 0 {
+		if s.alivePacks() < s.PredatorPacks {
+			s.spawnPack(s.packSeq)
+			s.packSeq++
+		}
+		s.nextPackTime = s.getExponentialTime(PackImmigrationRate)
+	}
+}
+
+// alivePacks compte les meutes dont au moins un loup est en vie
+func (s *Simulation) alivePacks() int {
+	packs := map[int]bool{}
+	for _, a := range s.environment.agents {
+		if p, ok := a.(*Predator); ok && p.IsAlive() {
+			packs[p.packID] = true
+		}
+	}
+	return len(packs)
 }
 
 func (s *Simulation) spawnAnimal() {
@@ -241,9 +384,7 @@ func (s *Simulation) spawnAnimal() {
 
 	for i := 0; i < 10; i++ {
 		typ := s.pickRandomAnimalType()
-		size := 32
-		if typ == Chicken { size = 20 }
-		if typ == Bull { size = 48 }
+		size := AnimalSize(typ)
 
 		safeW := float64(s.environment.width - size)
 		safeH := float64(s.environment.height - size)
@@ -259,16 +400,32 @@ func (s *Simulation) spawnAnimal() {
 	}
 }
 
-func (s *Simulation) spawnVegetable() {
+func (s *Simulation) spawnPack(packID int) {
+	size := 24
+	safeW := float64(s.environment.width - size)
+	safeH := float64(s.environment.height - size)
+	cx := rand.Float64() * safeW
+	cy := rand.Float64() * safeH
+
+	for i := 0; i < s.PackSize; i++ {
+		x := math.Min(safeW, math.Max(0, cx+rand.Float64()*40-20))
+		y := math.Min(safeH, math.Max(0, cy+rand.Float64()*40-20))
+		sprite := CreateSprite(x, y, size, size)
+		s.AddAgent(CreatePredator(fmt.Sprintf("Loup-%d", packID), sprite, packID))
+	}
+}
+
+func (s *Simulation) spawnVegetable() *Vegetable {
 	currentVegetables := 0
+	// Les buissons en attente de repousse comptent dans la limite
 	for _, o := range s.environment.objects {
-		if o.IsAlive() {
+		if veg, ok := o.(*Vegetable); ok && (veg.IsAlive() || veg.IsDormant()) {
 			currentVegetables++
 		}
 	}
 
 	if currentVegetables >= s.MaxPlants {
-		return
+		return nil
 	}
 
 	size := 16
@@ -279,39 +436,61 @@ func (s *Simulation) spawnVegetable() {
 		x := rand.Float64() * safeW
 		y := rand.Float64() * safeH
 		if s.environment.IsLocationFree(x, y, 20.0) {
-			s.globalIDCounter++
 			typ := s.pickRandomVegetableType()
 			sprite := CreateSprite(x, y, size, size)
-			veg := CreateVegetable(s.globalIDCounter, "Plant", sprite, typ)
+			veg := CreateVegetable(s.environment.newID(), "Plant", sprite, typ)
 			s.environment.AddObject(veg)
-			return
+			return veg
 		}
 	}
+	return nil
 }
 
 func (s *Simulation) RecordStats() {
-	humans, animals, veg := 0, 0, 0
+	humans, animals, veg, predators := 0, 0, 0, 0
 	cPrag, cCaut, cSelf, cColl := 0, 0, 0, 0
+	children, elders := 0, 0
+	food := []float64{}
 	
 	for _, a := range s.environment.agents {
 		if a.IsAlive() {
 			if h, ok := a.(*Human); ok {
 				humans++
+				food = append(food, float64(h.GetFoodReceived()))
+				switch h.GetLifeStage() {
+				case Child: children++
+				case Elder: elders++
+				}
 				switch h.GetProfile() {
 				case Pragmatic: cPrag++
 				case Cautious: cCaut++
 				case Selfish: cSelf++
 				case Collectivist: cColl++
 				}
-			} else if _, ok := a.(*Animal); ok { animals++ }
+			} else if _, ok := a.(*Animal); ok { animals++ } else if _, ok := a.(*Predator); ok { predators++ }
 		}
 	}
-	for _, o := range s.environment.objects { if o.IsAlive() { veg++ } }
+	for _, o := range s.environment.objects {
+		if _, ok := o.(*Vegetable); ok && o.IsAlive() { veg++ }
+	}
+
+	sus, inf, rec := countSIR(s.environment.agents)
+	fights, fightDeaths := s.environment.conflicts.flush()
 
 	s.History = append(s.History, TurnData{
 		Tick: s.currentStep,
-		HumansAlive: humans, AnimalsAlive: animals, VegetablesAlive: veg,
+		HumansAlive: humans, AnimalsAlive: animals, VegetablesAlive: veg, PredatorsAlive: predators,
 		CountPragmatic: cPrag, CountCautious: cCaut, CountSelfish: cSelf, CountCollectivist: cColl,
+		CooperationRate: s.environment.CooperationRate(),
+		GiniFood: giniCoefficient(food),
+		Day: s.GetDay(), Season: s.GetSeason(), Disasters: s.disasterMask(),
+		Susceptible: sus, Infected: inf, Recovered: rec,
+		Children: children, Elders: elders,
+		Tribes: s.tribeStats(),
+		TerritoryCells: s.environment.territory.cellCounts(),
+		Fights: fights, FightDeaths: fightDeaths,
+		Trade: s.environment.trades.flush(),
+		ToolOwners: toolOwners(s.environment.agents),
 	})
 }
 
@@ -325,4 +504,11 @@ func (s *Simulation) GetAllObjects() []Object {
 
 func (s *Simulation) GetHistory() []TurnData { 
 	return s.History 
+}
+
+// GetContractLog renvoie les tours du Contract Net Protocol
+func (s *Simulation) GetContractLog() []ContractRecord {
+	s.environment.contractMutex.Lock()
+	defer s.environment.contractMutex.Unlock()
+	return append([]ContractRecord{}, s.environment.contractLog...)
 }
\ No newline at end of file
@@ -0,0 +1,101 @@
+package simulation
+
+const (
+	TerritoryCell      = 40    // taille d'une case de territoire (pixels)
+	TerritoryDecay     = 0.998 // la présence s'efface si la tribu ne revient pas
+	TerritoryThreshold = 30.0  // présence nécessaire pour revendiquer une case
+)
+
+// territory : présence accumulée par chaque tribu dans chaque case de la carte.
+// Mis à jour entre deux ticks, lu par les agents pendant le tick.
+type territory struct {
+	cols, rows int
+	presence   [][MaxTribes]float64
+	owner      []int // -1 : case libre
+}
+
+func createTerritory(width, height int) territory {
+	cols := (width + TerritoryCell - 1) / TerritoryCell
+	rows := (height + TerritoryCell - 1) / TerritoryCell
+	t := territory{
+		cols:     cols,
+		rows:     rows,
+		presence: make([][MaxTribes]float64, cols*rows),
+		owner:    make([]int, cols*rows),
+	}
+	for i := range t.owner {
+		t.owner[i] = -1
+	}
+	return t
+}
+
+func (t *territory) cellAt(pos Position) int {
+	col := int(pos.X) / TerritoryCell
+	row := int(pos.Y) / TerritoryCell
+	if col < 0 {
+		col = 0
+	}
+	if row < 0 {
+		row = 0
+	}
+	if col >= t.cols {
+		col = t.cols - 1
+	}
+	if row >= t.rows {
+		row = t.rows - 1
+	}
+	return row*t.cols + col
+}
+
+// ownerAt renvoie la tribu propriétaire de la case contenant pos (-1 si libre)
+func (t *territory) ownerAt(pos Position) int {
+	return t.owner[t.cellAt(pos)]
+}
+
+// update : chaque humain vivant marque sa case, puis chaque case revient à la tribu la plus présente
+func (t *territory) update(agents []Agent) {
+	for i := range t.presence {
+		for tribe := range t.presence[i] {
+			t.presence[i][tribe] *= TerritoryDecay
+		}
+	}
+	for _, a := range agents {
+		if h, ok := a.(*Human); ok && h.IsAlive() {
+			t.presence[t.cellAt(h.GetSprite().Position)][h.tribe]++
+		}
+	}
+	for i, p := range t.presence {
+		best, owner := TerritoryThreshold, -1
+		for tribe, v := range p {
+			if v > best {
+				best, owner = v, tribe
+			}
+		}
+		t.owner[i] = owner
+	}
+}
+
+func (e *Environment) updateTerritory() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.territory.update(e.agents)
+}
+
+// cellCounts : nombre de cases possédées par chaque tribu
+func (t *territory) cellCounts() [MaxTribes]int {
+	var counts [MaxTribes]int
+	for _, o := range t.owner {
+		if o >= 0 {
+			counts[o]++
+		}
+	}
+	return counts
+}
+
+// GetTerritory renvoie le propriétaire de chaque case (ligne par ligne) et la taille d'une case
+func (s *Simulation) GetTerritory() (owners []int, cols int, cell int) {
+	s.environment.mutex.RLock()
+	defer s.environment.mutex.RUnlock()
+	t := &s.environment.territory
+	return append([]int{}, t.owner...), t.cols, TerritoryCell
+}
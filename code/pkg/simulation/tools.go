@@ -0,0 +1,357 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+const (
+	MaterialSize     = 10
+	MaterialRate     = 0.03 // matériaux apparus par tick (processus de Poisson)
+	MaxMaterials     = 40
+	InitMaterials    = 15
+	CraftDuration    = 60 // ticks de travail pour fabriquer un outil
+	CraftEnergyCost  = 20
+	CollectBase      = 80.0  // utilité de ramasser un matériau manquant
+	CraftBase        = 150.0 // utilité de fabriquer un outil manquant
+	SpearFactor      = 1.6   // dégâts de chasse multipliés par la lance
+	BasketCapacity   = 150   // place ajoutée au sac par le panier
+	ToolWornFraction = 0.2   // en dessous, on songe à remplacer l'outil
+)
+
+type MaterialKind int
+
+const (
+	Stone MaterialKind = iota
+	Wood
+	Flint
+	MaterialKinds
+)
+
+func (m MaterialKind) String() string {
+	switch m {
+	case Stone:
+		return "pierre"
+	case Wood:
+		return "bois"
+	default:
+		return "silex"
+	}
+}
+
+type ToolKind int
+
+const (
+	Spear ToolKind = iota
+	Basket
+	ToolKinds
+)
+
+func (t ToolKind) String() string {
+	if t == Spear {
+		return "lance"
+	}
+	return "panier"
+}
+
+// recipe : matériaux nécessaires à la fabrication
+func (t ToolKind) recipe() [MaterialKinds]int {
+	if t == Spear {
+		return [MaterialKinds]int{Stone: 1, Wood: 1, Flint: 1}
+	}
+	return [MaterialKinds]int{Wood: 2}
+}
+
+// durability : nombre d'utilisations avant que l'outil ne casse
+func (t ToolKind) durability() int {
+	if t == Spear {
+		return 30
+	}
+	return 40
+}
+
+// investment : propension à préparer l'avenir en fabriquant des outils selon le profil
+func investment(p Profile) float64 {
+	switch p {
+	case Pragmatic:
+		return 1.5
+	case Cautious:
+		return 1.0
+	case Collectivist:
+		return 0.8
+	default:
+		return 0.5
+	}
+}
+
+// Material : pierre, bois ou silex à ramasser
+type Material struct {
+	ObjectParams
+	kind  MaterialKind
+	mutex sync.Mutex
+}
+
+func CreateMaterial(id uint, sprite Sprite, kind MaterialKind) *Material {
+	return &Material{
+		ObjectParams: ObjectParams{
+			id:     id,
+			name:   "Material",
+			alive:  true,
+			sprite: sprite,
+		},
+		kind: kind,
+	}
+}
+
+func (m *Material) GetKind() MaterialKind {
+	return m.kind
+}
+
+// pickUp : un seul humain peut ramasser le matériau
+func (m *Material) pickUp() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.alive {
+		return false
+	}
+	m.alive = false
+	return true
+}
+
+func (s *Simulation) spawnMaterial() *Material {
+	count := 0
+	for _, o := range s.environment.objects {
+		if _, ok := o.(*Material); ok && o.IsAlive() {
+			count++
+		}
+	}
+	if count >= MaxMaterials {
+		return nil
+	}
+
+	safeW := float64(s.environment.width - MaterialSize)
+	safeH := float64(s.environment.height - MaterialSize)
+	for i := 0; i < 10; i++ {
+		x := rand.Float64() * safeW
+		y := rand.Float64() * safeH
+		if s.environment.IsLocationFree(x, y, 20.0) {
+			m := CreateMaterial(s.environment.newID(), CreateSprite(x, y, MaterialSize, MaterialSize), MaterialKind(rand.Intn(int(MaterialKinds))))
+			s.environment.AddObject(m)
+			return m
+		}
+	}
+	return nil
+}
+
+// GetMaterials renvoie les matériaux portés (pierre, bois, silex)
+func (h *Human) GetMaterials() [MaterialKinds]int {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	return h.bag.materials
+}
+
+// GetTools renvoie l'usure restante de chaque outil (0 : pas d'outil)
+func (h *Human) GetTools() [ToolKinds]int {
+	h.bag.mutex.Lock()
+	defer h.bag.mutex.Unlock()
+	return h.bag.tools
+}
+
+func (h *Human) hasTool(t ToolKind) bool {
+	return h.GetTools()[t] > 0
+}
+
+// useTool use l'outil d'un cran et signale dans le journal s'il vient de casser
+func (h *Human) useTool(t ToolKind, env *Environment) {
+	h.bag.mutex.Lock()
+	broken := false
+	if h.bag.tools[t] > 0 {
+		h.bag.tools[t]--
+		broken = h.bag.tools[t] == 0
+	}
+	h.bag.mutex.Unlock()
+	if broken {
+		env.LogEvent(EventCraft, "%s : outil casse (%s)", h.GetName(), t)
+	}
+}
+
+// wantsTool : l'outil manque ou est presque usé
+func (h *Human) wantsTool(t ToolKind) bool {
+	return float64(h.GetTools()[t]) < float64(t.durability())*ToolWornFraction
+}
+
+// canCraft : assez de matériaux pour la recette
+func (h *Human) canCraft(t ToolKind) bool {
+	have := h.GetMaterials()
+	for m, n := range t.recipe() {
+		if have[m] < n {
+			return false
+		}
+	}
+	return true
+}
+
+// needsMaterial : le matériau manque pour un outil voulu ou pour faire du feu
+func (h *Human) needsMaterial(kind MaterialKind) bool {
+	have := h.GetMaterials()
+	if have[kind] < fireKit[kind] {
+		return true
+	}
+	for t := ToolKind(0); t < ToolKinds; t++ {
+		if h.wantsTool(t) && have[kind] < t.recipe()[kind] {
+			return true
+		}
+	}
+	return false
+}
+
+// huntDamage : la lance augmente les dégâts de chasse
+func (h *Human) huntDamage(base int, env *Environment) int {
+	if !h.hasTool(Spear) {
+		return base
+	}
+	h.useTool(Spear, env)
+	return int(math.Round(float64(base) * SpearFactor))
+}
+
+// CollectMaterialAction : ramasser un matériau utile à un outil
+type CollectMaterialAction struct {
+	TargetID uint
+}
+
+func (c *CollectMaterialAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+	m, ok := env.findObject(c.TargetID).(*Material)
+	if !ok || !m.IsAlive() {
+		h.currentAction = nil
+		return
+	}
+
+	h.hunger += HungerCost
+	if h.hunger > MaxHunger {
+		h.hunger = MaxHunger
+	}
+
+	if moveTowards(a, m.GetSprite().Position, env) {
+		if m.pickUp() {
+			h.bag.mutex.Lock()
+			h.bag.materials[m.kind]++
+			h.bag.mutex.Unlock()
+		}
+		h.currentAction = nil
+	}
+}
+
+func (c *CollectMaterialAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.GetLifeStage() == Child || h.hunger > HungryThreshold {
+		return 0.0
+	}
+
+	var target *Material
+	minDist := math.Inf(1)
+	for _, obj := range h.visibleObjects {
+		m, ok := obj.(*Material)
+		if !ok || !m.IsAlive() || !h.needsMaterial(m.kind) {
+			continue
+		}
+		d := h.GetSprite().Position.DistanceTo(m.GetSprite().Position)
+		if d < minDist {
+			minDist = d
+			target = m
+		}
+	}
+	if target == nil {
+		return 0.0
+	}
+	c.TargetID = target.GetID()
+
+	utility := CollectBase*investment(h.profile) - float64(h.hunger)*0.5 - minDist*0.1
+	return math.Max(0.0, utility)
+}
+
+// CraftAction : transformer des matériaux en outil
+type CraftAction struct {
+	Tool ToolKind
+}
+
+func (c *CraftAction) Execute(a Agent, env *Environment) {
+	h := a.(*Human)
+	if h.actionDuration < CraftDuration {
+		return
+	}
+
+	h.bag.mutex.Lock()
+	for m, n := range c.Tool.recipe() {
+		if h.bag.materials[m] < n {
+			h.bag.mutex.Unlock()
+			h.currentAction = nil
+			return
+		}
+	}
+	for m, n := range c.Tool.recipe() {
+		h.bag.materials[m] -= n
+	}
+	h.bag.tools[c.Tool] = c.Tool.durability()
+	h.bag.mutex.Unlock()
+
+	if h.energy >= CraftEnergyCost {
+		h.energy -= CraftEnergyCost
+	} else {
+		h.energy = 0
+	}
+	env.tools.record(h.profile, c.Tool)
+	env.LogEvent(EventCraft, "%s fabrique un outil : %s", h.GetName(), c.Tool)
+	h.currentAction = nil
+}
+
+func (c *CraftAction) evaluateUtility(a Agent, env *Environment) float64 {
+	h := a.(*Human)
+	if h.GetLifeStage() == Child || h.hunger > HungryThreshold || h.energy < CraftEnergyCost {
+		return 0.0
+	}
+	for t := ToolKind(0); t < ToolKinds; t++ {
+		if h.wantsTool(t) && h.canCraft(t) {
+			c.Tool = t
+			utility := CraftBase*investment(h.profile) - float64(h.hunger)*0.5
+			return math.Max(0.0, utility)
+		}
+	}
+	return 0.0
+}
+
+// toolLog : outils fabriqués depuis le début, par profil
+type toolLog struct {
+	crafted [ProfileCount][ToolKinds]int
+	mutex   sync.Mutex
+}
+
+func (l *toolLog) record(p Profile, t ToolKind) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.crafted[p][t]++
+}
+
+// GetToolsCrafted renvoie le nombre d'outils fabriqués par profil
+func (s *Simulation) GetToolsCrafted() [ProfileCount][ToolKinds]int {
+	l := &s.environment.tools
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.crafted
+}
+
+// toolOwners compte les humains équipés de chaque outil, par profil
+func toolOwners(agents []Agent) [ProfileCount][ToolKinds]int {
+	var owners [ProfileCount][ToolKinds]int
+	for _, a := range agents {
+		if h, ok := a.(*Human); ok && h.IsAlive() {
+			for t, wear := range h.GetTools() {
+				if wear > 0 {
+					owners[h.profile][t]++
+				}
+			}
+		}
+	}
+	return owners
+}
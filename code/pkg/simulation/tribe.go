@@ -0,0 +1,189 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	MaxTribes     = 4
+	OutsiderTrust = 0.1 // confiance initiale envers un membre d'une autre tribu
+)
+
+// StartArea : zone de départ d'une tribu
+type StartArea int
+
+const (
+	AreaAnywhere StartArea = iota
+	AreaWest
+	AreaEast
+	AreaNorth
+	AreaSouth
+	AreaCenter
+	AreaCount
+)
+
+func (a StartArea) String() string {
+	switch a {
+	case AreaWest:
+		return "Ouest"
+	case AreaEast:
+		return "Est"
+	case AreaNorth:
+		return "Nord"
+	case AreaSouth:
+		return "Sud"
+	case AreaCenter:
+		return "Centre"
+	default:
+		return "Partout"
+	}
+}
+
+func (a StartArea) zone(width, height float64) Zone {
+	switch a {
+	case AreaWest:
+		return Zone{0, 0, width / 3, height}
+	case AreaEast:
+		return Zone{width * 2 / 3, 0, width / 3, height}
+	case AreaNorth:
+		return Zone{0, 0, width, height / 3}
+	case AreaSouth:
+		return Zone{0, height * 2 / 3, width, height / 3}
+	case AreaCenter:
+		return Zone{width / 3, height / 3, width / 3, height / 3}
+	default:
+		return Zone{0, 0, width, height}
+	}
+}
+
+// TribeConfig : poids des profils (indexés par Profile) et zone de départ d'une tribu
+type TribeConfig struct {
+	Weights [ProfileCount]float64
+	Area    StartArea
+}
+
+func DefaultTribes() [MaxTribes]TribeConfig {
+	areas := [MaxTribes]StartArea{AreaWest, AreaEast, AreaNorth, AreaSouth}
+	tribes := [MaxTribes]TribeConfig{}
+	for i := range tribes {
+		tribes[i] = TribeConfig{Weights: [ProfileCount]float64{1, 1, 1, 1}, Area: areas[i]}
+	}
+	return tribes
+}
+
+// pickProfile tire un profil selon les poids de la tribu
+func (t TribeConfig) pickProfile() Profile {
+	total := 0.0
+	for _, w := range t.Weights {
+		total += w
+	}
+	if total <= 0 {
+		return Profile(rand.Intn(len(t.Weights)))
+	}
+	r := rand.Float64() * total
+	for p, w := range t.Weights {
+		if r < w {
+			return Profile(p)
+		}
+		r -= w
+	}
+	return Collectivist
+}
+
+// SetTribes remplace la population unique par plusieurs tribus (InitHumans est réparti entre elles)
+func (s *Simulation) SetTribes(tribes []TribeConfig) {
+	if len(tribes) > MaxTribes {
+		tribes = tribes[:MaxTribes]
+	}
+	s.Tribes = tribes
+}
+
+func (s *Simulation) GetTribeCount() int {
+	if len(s.Tribes) == 0 {
+		return 1
+	}
+	return len(s.Tribes)
+}
+
+// spawnHuman crée un humain de départ, dans la zone de sa tribu s'il y en a
+func (s *Simulation) spawnHuman(i int) *Human {
+	safeW := float64(s.environment.width - 16)
+	safeH := float64(s.environment.height - 16)
+
+	profile := s.PickRandomProfile()
+	zone := Zone{0, 0, safeW, safeH}
+	tribe := 0
+	if len(s.Tribes) > 0 {
+		tribe = i % len(s.Tribes)
+		profile = s.Tribes[tribe].pickProfile()
+		zone = s.Tribes[tribe].Area.zone(safeW, safeH)
+	}
+
+	pos := CreatePosition(zone.X+rand.Float64()*zone.W, zone.Y+rand.Float64()*zone.H)
+	sprite := CreateSprite(pos.X, pos.Y, 16, 16)
+	h := CreateHuman(fmt.Sprintf("H-%d", i), 100, sprite, 50, 100, profile, "Base")
+	h.tribe = tribe
+	return h
+}
+
+func (h *Human) GetTribe() int {
+	return h.tribe
+}
+
+func (h *Human) sameTribe(other *Human) bool {
+	return h.tribe == other.tribe
+}
+
+// meetOutsiders : on se méfie d'emblée des membres d'une autre tribu
+func (h *Human) meetOutsiders() {
+	h.trust.mutex.Lock()
+	defer h.trust.mutex.Unlock()
+	for _, ag := range h.visibleAgents {
+		other, ok := ag.(*Human)
+		if !ok || h.sameTribe(other) {
+			continue
+		}
+		if _, known := h.trust.scores[other.GetID()]; !known {
+			h.trust.scores[other.GetID()] = OutsiderTrust
+		}
+	}
+}
+
+// tribeMessages ne garde que les messages des membres de la tribu
+func (h *Human) tribeMessages(env *Environment, msgs []Message) []Message {
+	kept := []Message{}
+	for _, msg := range msgs {
+		if sender, ok := env.findAgent(msg.From).(*Human); ok && !h.sameTribe(sender) {
+			continue
+		}
+		kept = append(kept, msg)
+	}
+	return kept
+}
+
+// TribeStats : population, nourriture moyenne reçue et campement par tribu
+type TribeStats struct {
+	Alive    [MaxTribes]int
+	MeanFood [MaxTribes]float64
+	Stock    [MaxTribes]int
+	Shelter  [MaxTribes]float64
+}
+
+func (s *Simulation) tribeStats() TribeStats {
+	var stats TribeStats
+	food := [MaxTribes]float64{}
+	for _, a := range s.environment.agents {
+		if h, ok := a.(*Human); ok && h.IsAlive() {
+			stats.Alive[h.tribe]++
+			food[h.tribe] += float64(h.GetFoodReceived())
+		}
+	}
+	for t := range food {
+		if stats.Alive[t] > 0 {
+			stats.MeanFood[t] = food[t] / float64(stats.Alive[t])
+		}
+	}
+	stats.Stock, stats.Shelter = s.environment.campStats()
+	return stats
+}
@@ -0,0 +1,76 @@
+package simulation
+
+import "sync"
+
+const (
+	InitialTrust       = 0.5
+	DistrustThreshold  = 0.2
+	TrustContribution  = 0.05
+	TrustFreeRide      = 0.15
+	TrustShare         = 0.1
+	TrustPromiseKept   = 0.05
+	TrustPromiseBroken = 0.15
+)
+
+// trustBook : confiance accordée aux autres humains (entre 0 et 1)
+type trustBook struct {
+	scores map[uint]float64
+	mutex  sync.Mutex
+}
+
+func (h *Human) GetTrust(id uint) float64 {
+	h.trust.mutex.Lock()
+	defer h.trust.mutex.Unlock()
+	if t, ok := h.trust.scores[id]; ok {
+		return t
+	}
+	return InitialTrust
+}
+
+// GetTrustScores renvoie une copie des scores (pour l'affichage)
+func (h *Human) GetTrustScores() map[uint]float64 {
+	h.trust.mutex.Lock()
+	defer h.trust.mutex.Unlock()
+	scores := make(map[uint]float64, len(h.trust.scores))
+	for id, t := range h.trust.scores {
+		scores[id] = t
+	}
+	return scores
+}
+
+// adjustTrust peut être appelé depuis la goroutine d'un autre agent
+func (h *Human) adjustTrust(id uint, delta float64) {
+	if id == h.GetID() {
+		return
+	}
+	h.trust.mutex.Lock()
+	defer h.trust.mutex.Unlock()
+	t, ok := h.trust.scores[id]
+	if !ok {
+		t = InitialTrust
+	}
+	t += delta
+	if t < 0 {
+		t = 0
+	}
+	if t > 1 {
+		t = 1
+	}
+	h.trust.scores[id] = t
+}
+
+// observeHunt : chaque chasseur juge la contribution des autres à la mise à mort
+func observeHunt(hunters []*Human, damage map[uint]int) {
+	for _, observer := range hunters {
+		for _, other := range hunters {
+			if other == observer {
+				continue
+			}
+			if damage[other.GetID()] > 0 {
+				observer.adjustTrust(other.GetID(), TrustContribution)
+			} else {
+				observer.adjustTrust(other.GetID(), -TrustFreeRide)
+			}
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package simulation
 
+import (
+	"math"
+	"sync"
+)
+
 type vegetableType int
 
 const (
@@ -10,9 +15,30 @@ const (
 
 const HungerValue = 5
 
+const (
+	RegrowDelay = 300
+	UnripeBias  = 100.0
+)
+
+type GrowthStage int
+
+const (
+	Seedling GrowthStage = iota
+	Growing
+	Ripe
+)
+
 type Vegetable struct {
 	ObjectParams
-	typ vegetableType
+	typ   vegetableType
+	mutex sync.Mutex
+
+	// Croissance (ticks depuis la pousse) et repousse des buissons récoltés
+	growth  float64
+	dormant int
+
+	// Qualité selon la saison (1 = normale)
+	quality float64
 }
 
 func CreateVegetable(id uint, name string, sprite Sprite, typ vegetableType) *Vegetable {
@@ -23,7 +49,8 @@ func CreateVegetable(id uint, name string, sprite Sprite, typ vegetableType) *Ve
 			alive:  true,
 			sprite: sprite,
 		},
-		typ: typ,
+		typ:     typ,
+		quality: 1,
 	}
 }
 
@@ -35,19 +62,104 @@ func (v *Vegetable) GetSprite() Sprite {
 	return v.sprite
 }
 
+// GetHungerValue : la valeur nutritive est proportionnelle à la maturité et dépend de la saison
 func (v *Vegetable) GetHungerValue() uint {
+	var base float64
 	switch v.typ {
 	case Carrot:
-		return 60
+		base = 60
 	case Lettuce:
-		return 40
+		base = 40
 	case Berry:
-		return 25
+		base = 25
+	}
+	v.mutex.Lock()
+	quality := v.quality
+	v.mutex.Unlock()
+	return uint(base * v.GetRipeness() * quality)
+}
+
+// growthDuration : nombre de ticks pour passer de la pousse à la maturité
+func (t vegetableType) growthDuration() int {
+	switch t {
+	case Carrot:
+		return 800
+	case Lettuce:
+		return 500
 	default:
-		return 0
+		return 300
 	}
 }
 
-func (v *Vegetable) Consume() {
+func (v *Vegetable) GetRipeness() float64 {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return math.Min(1, v.growth/float64(v.typ.growthDuration()))
+}
+
+func (v *Vegetable) GetStage() GrowthStage {
+	r := v.GetRipeness()
+	switch {
+	case r >= 1:
+		return Ripe
+	case r >= 0.33:
+		return Growing
+	default:
+		return Seedling
+	}
+}
+
+// Grow est appelé à chaque tick par la simulation (aucun agent ne tourne)
+func (v *Vegetable) Grow(season SeasonParams) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.quality = season.PlantGrowth
+	if v.alive {
+		v.growth += season.PlantGrowth
+		return
+	}
+	if v.dormant > 0 {
+		v.dormant--
+		if v.dormant == 0 {
+			// Le buisson repousse au même endroit
+			v.growth = 0
+			pos := v.sprite.Position
+			v.Spawn(pos.X, pos.Y)
+		}
+	}
+}
+
+// destroy : la plante est détruite, même un buisson ne repoussera pas
+func (v *Vegetable) destroy() {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
 	v.alive = false
+	v.dormant = 0
+}
+
+// IsDormant : un buisson récolté reste dans l'environnement en attendant de repousser
+func (v *Vegetable) IsDormant() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return !v.alive && v.dormant > 0
+}
+
+func (v *Vegetable) IsAlive() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.alive
+}
+
+// Consume renvoie false si un autre agent l'a mangé avant (humain ou animal)
+func (v *Vegetable) Consume() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if !v.alive {
+		return false
+	}
+	v.alive = false
+	if v.typ == Berry {
+		v.dormant = RegrowDelay
+	}
+	return true
 }